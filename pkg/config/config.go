@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -10,7 +11,17 @@ import (
 type Config struct {
 	AppPort       string
 	DBUrl         string
+	DBReplicaUrl  string // ใช้เฉพาะฝั่ง Arena: read replica สำหรับประวัติการดวล (ว่าง = ไม่มี)
+	DBMaxOpen     int    // 0 = ค่า default ของ database package
+	DBMaxIdle     int    // 0 = ค่า default ของ database package
+	DBMaxLifetime int    // อายุสูงสุดของ connection เป็นวินาที (0 = ไม่จำกัด)
+	DBRetries     int    // ต่อ DB ไม่ติดตอน start ลองใหม่กี่ครั้ง (0 = ค่า default, < 0 = ไม่ลองใหม่)
 	DuelistTarget string // ใช้เฉพาะฝั่ง Arena
+	MaxTurns      int    // ใช้เฉพาะฝั่ง Arena: จำนวนเทิร์นสูงสุดต่อการดวล (0 = ค่า default ของ domain)
+	EloK          int    // ใช้เฉพาะฝั่ง Arena: ค่า K ของ Elo (0 = ค่า default ของ domain)
+	CacheSize     int    // ใช้เฉพาะฝั่ง Arena: จำนวน Cowboy ที่ cache ได้ (0 = ค่า default)
+	CacheTTL      int    // ใช้เฉพาะฝั่ง Arena: อายุ cache เป็นวินาที (0 = ค่า default)
+	AutoMigrate   bool   // รัน migration ที่ค้างตอน start (สำหรับ dev / sqlite://:memory:)
 }
 
 // LoadConfig : โหลดค่า Config ทั้งหมดทีเดียว
@@ -24,7 +35,17 @@ func LoadConfig() *Config {
 		// ฟังก์ชัน getEnv ช่วยเช็คค่า default ให้
 		AppPort:       getEnv("APP_PORT", ""), // ใช้ชื่อกลางๆ เดี๋ยวไป override ใน main
 		DBUrl:         getEnv("DB_DSN", ""),
+		DBReplicaUrl:  getEnv("ARENA_DB_REPLICA_DSN", ""),
+		DBMaxOpen:     getEnvInt("DB_MAX_OPEN_CONNS", 0),
+		DBMaxIdle:     getEnvInt("DB_MAX_IDLE_CONNS", 0),
+		DBMaxLifetime: getEnvInt("DB_CONN_MAX_LIFETIME", 0),
+		DBRetries:     getEnvInt("DB_CONNECT_RETRIES", 0),
 		DuelistTarget: getEnv("DUELIST_TARGET", ""),
+		MaxTurns:      getEnvInt("ARENA_MAX_TURNS", 0),
+		EloK:          getEnvInt("ARENA_ELO_K", 0),
+		CacheSize:     getEnvInt("ARENA_CACHE_SIZE", 0),
+		CacheTTL:      getEnvInt("ARENA_CACHE_TTL", 0),
+		AutoMigrate:   getEnvBool("DB_AUTO_MIGRATE", false),
 	}
 }
 
@@ -35,3 +56,31 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvInt : เหมือน getEnv แต่แปลงเป็น int (ค่าผิดรูปแบบจะใช้ fallback)
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("⚠️  Note: %s=%q is not a number, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getEnvBool : เหมือน getEnv แต่แปลงเป็น bool (ค่าผิดรูปแบบจะใช้ fallback)
+func getEnvBool(key string, fallback bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("⚠️  Note: %s=%q is not a boolean, using %t", key, value, fallback)
+		return fallback
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// MigrationUsage : วิธีใช้ subcommand (แสดงเมื่อสั่งผิด)
+const MigrationUsage = "usage: <service> [migrate | rollback [steps] | status]"
+
+// RunMigrationCommand : subcommand ของ cmd สำหรับจัดการ schema
+//
+//	migrate          รันทุก migration ที่ค้างอยู่
+//	rollback [n]     ย้อน n ตัวล่าสุด (ไม่ระบุ = 1)
+//	status           แสดงว่าตัวไหนรันแล้ว / ค้างอยู่
+func RunMigrationCommand(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(MigrationUsage)
+	}
+
+	switch args[0] {
+	case "migrate":
+		ran, err := m.Up(ctx)
+		for _, mg := range ran {
+			fmt.Fprintf(out, "✅ Applied %d_%s\n", mg.Version, mg.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(ran) == 0 {
+			fmt.Fprintln(out, "👌 Schema is already up to date")
+		}
+		return nil
+
+	case "rollback":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return errors.New("rollback steps must be a positive integer")
+			}
+			steps = n
+		}
+		rolled, err := m.Down(ctx, steps)
+		for _, mg := range rolled {
+			fmt.Fprintf(out, "↩️  Rolled back %d_%s\n", mg.Version, mg.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolled) == 0 {
+			fmt.Fprintln(out, "👌 Nothing to roll back")
+		}
+		return nil
+
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", st.Version, st.Name, applied)
+		}
+		return w.Flush()
+	}
+	return fmt.Errorf("unknown command %q\n%s", args[0], MigrationUsage)
+}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration : การเปลี่ยน schema 1 ขั้น (Version ต้องเรียงจากน้อยไปมากและไม่ซ้ำกันภายใน service)
+// Up / Down ห้ามอ้าง model ตัวจริงของ repository เพราะ model จะเปลี่ยนตามเวลา
+// ให้ใช้ struct ที่ freeze ไว้ใน migration นั้นๆ หรือ SQL ตรงๆ แทน
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error // nil = ย้อนไม่ได้
+}
+
+// MigrationStatus : สถานะของแต่ละ migration (AppliedAt nil = ยังไม่ได้รัน)
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+var (
+	// ErrSchemaOutdated : DB ยังไม่ได้ migrate ถึง version ล่าสุดของโค้ด
+	ErrSchemaOutdated = errors.New("database schema is not up to date")
+	// ErrSchemaTooNew : DB ถูก migrate ด้วยโค้ดที่ใหม่กว่าตัวที่กำลังรัน
+	ErrSchemaTooNew = errors.New("database schema is newer than this build")
+)
+
+// schemaMigration : 1 แถว = 1 migration ที่รันไปแล้ว (หลาย service ใช้ DB เดียวกันได้ แยกด้วย service)
+type schemaMigration struct {
+	Service   string `gorm:"primaryKey;size:64"`
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator : รัน migration ของ service เดียว
+type Migrator struct {
+	db         *gorm.DB
+	service    string
+	migrations []Migration
+}
+
+// NewMigrator : migrations ต้องเรียงตาม Version อยู่แล้ว (ผิดลำดับ / ซ้ำ = error ตั้งแต่ตอนสร้าง)
+func NewMigrator(db *gorm.DB, service string, migrations []Migration) (*Migrator, error) {
+	last := 0
+	for _, m := range migrations {
+		if m.Version <= last {
+			return nil, fmt.Errorf("migration %d_%s is out of order", m.Version, m.Name)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d_%s has no Up", m.Version, m.Name)
+		}
+		last = m.Version
+	}
+	return &Migrator{db: db, service: service, migrations: migrations}, nil
+}
+
+// applied : version ที่รันแล้ว -> เวลาที่รัน (อ่านอย่างเดียว ยังไม่มีตาราง = ยังไม่เคยรันอะไร)
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return map[int]time.Time{}, nil
+	}
+	var rows []schemaMigration
+	if err := db.Where("service = ?", m.service).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	done := make(map[int]time.Time, len(rows))
+	for _, r := range rows {
+		done[r.Version] = r.AppliedAt
+	}
+	return done, nil
+}
+
+// Up : รันทุก migration ที่ยังไม่ได้รัน ทีละตัวตามลำดับ (แต่ละตัวอยู่ใน transaction ของตัวเอง)
+// หมายเหตุ: MySQL commit DDL ทันที ถ้า migration พังกลางทางอาจต้องเก็บกวาดเอง
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	// ตารางบันทึก migration สร้างเฉพาะตอน Up (status / EnsureCurrent ต้องไม่แตะ schema)
+	if err := CreateTable(m.db.WithContext(ctx), &schemaMigration{}); err != nil {
+		return nil, err
+	}
+	done, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	for _, mg := range m.migrations {
+		if _, ok := done[mg.Version]; ok {
+			continue
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := mg.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Service:   m.service,
+				Version:   mg.Version,
+				Name:      mg.Name,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+		if err != nil {
+			return ran, fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+		ran = append(ran, mg)
+	}
+	return ran, nil
+}
+
+// Down : ย้อน migration ล่าสุดที่รันไปแล้ว steps ตัว (ใหม่สุดก่อน)
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rolled []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(rolled) < steps; i-- {
+		mg := m.migrations[i]
+		if _, ok := done[mg.Version]; !ok {
+			continue
+		}
+		if mg.Down == nil {
+			return rolled, fmt.Errorf("migration %d_%s cannot be rolled back", mg.Version, mg.Name)
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := mg.Down(tx); err != nil {
+				return err
+			}
+			return tx.Where("service = ? AND version = ?", m.service, mg.Version).
+				Delete(&schemaMigration{}).Error
+		})
+		if err != nil {
+			return rolled, fmt.Errorf("rollback %d_%s: %w", mg.Version, mg.Name, err)
+		}
+		rolled = append(rolled, mg)
+	}
+	return rolled, nil
+}
+
+// Status : สถานะของทุก migration ที่โค้ดรู้จัก เรียงตาม Version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		st := MigrationStatus{Version: mg.Version, Name: mg.Name}
+		if at, ok := done[mg.Version]; ok {
+			st.AppliedAt = &at
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// EnsureCurrent : ใช้ตอน start service ไม่ยอมรันถ้า schema ไม่ตรงกับโค้ด
+func (m *Migrator) EnsureCurrent(ctx context.Context) error {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int]bool, len(m.migrations))
+	pending := 0
+	for _, mg := range m.migrations {
+		known[mg.Version] = true
+		if _, ok := done[mg.Version]; !ok {
+			pending++
+		}
+	}
+	for v := range done {
+		if !known[v] {
+			return fmt.Errorf("%w: migration %d is applied but unknown to %s", ErrSchemaTooNew, v, m.service)
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d pending migration(s) for %s", ErrSchemaOutdated, pending, m.service)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type widgetV1 struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+func (widgetV1) TableName() string {
+	return "widgets"
+}
+
+type widgetV2 struct {
+	Color string `gorm:"size:16;default:red;index"`
+}
+
+func (widgetV2) TableName() string {
+	return "widgets"
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "create_widgets",
+			Up:      func(tx *gorm.DB) error { return CreateTable(tx, &widgetV1{}) },
+			Down:    func(tx *gorm.DB) error { return tx.Migrator().DropTable("widgets") },
+		},
+		{
+			Version: 2,
+			Name:    "add_widget_color",
+			Up: func(tx *gorm.DB) error {
+				if err := AddColumns(tx, &widgetV2{}, "color"); err != nil {
+					return err
+				}
+				return CreateIndexes(tx, &widgetV2{}, "idx_widgets_color")
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := DropIndexes(tx, &widgetV2{}, "idx_widgets_color"); err != nil {
+					return err
+				}
+				return DropColumns(tx, &widgetV2{}, "color")
+			},
+		},
+		{
+			Version: 3,
+			Name:    "create_gadgets",
+			Up:      func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE gadgets (id integer primary key)").Error },
+			Down:    func(tx *gorm.DB) error { return tx.Migrator().DropTable("gadgets") },
+		},
+	}
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newTestMigrator(t *testing.T, db *gorm.DB, migrations []Migration) *Migrator {
+	t.Helper()
+	m, err := NewMigrator(db, "test", migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func appliedVersions(t *testing.T, m *Migrator) []int {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []int
+	for _, st := range statuses {
+		if st.AppliedAt != nil {
+			versions = append(versions, st.Version)
+		}
+	}
+	return versions
+}
+
+func TestNewMigratorRejectsBadOrder(t *testing.T) {
+	up := func(*gorm.DB) error { return nil }
+	tests := []struct {
+		name       string
+		migrations []Migration
+		wantErr    bool
+	}{
+		{"ordered", []Migration{{Version: 1, Up: up}, {Version: 2, Up: up}}, false},
+		{"gap is fine", []Migration{{Version: 1, Up: up}, {Version: 5, Up: up}}, false},
+		{"duplicate", []Migration{{Version: 1, Up: up}, {Version: 1, Up: up}}, true},
+		{"descending", []Migration{{Version: 2, Up: up}, {Version: 1, Up: up}}, true},
+		{"zero version", []Migration{{Version: 0, Up: up}}, true},
+		{"missing up", []Migration{{Version: 1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMigrator(nil, "test", tt.migrations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadPathsDoNotTouchSchema(t *testing.T) {
+	db := openTestDB(t)
+	m := newTestMigrator(t, db, testMigrations())
+	ctx := context.Background()
+
+	if got := appliedVersions(t, m); len(got) != 0 {
+		t.Fatalf("applied = %v, want none", got)
+	}
+	if err := m.EnsureCurrent(ctx); !errors.Is(err, ErrSchemaOutdated) {
+		t.Fatalf("EnsureCurrent = %v, want ErrSchemaOutdated", err)
+	}
+	if rolled, err := m.Down(ctx, 1); err != nil || len(rolled) != 0 {
+		t.Fatalf("Down = %v, %v, want nothing", rolled, err)
+	}
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		t.Fatal("read paths created schema_migrations")
+	}
+}
+
+func TestUpDownStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		downSteps   int
+		wantApplied []int
+		wantTables  map[string]bool
+	}{
+		{"no rollback", 0, []int{1, 2, 3}, map[string]bool{"widgets": true, "gadgets": true}},
+		{"one step", 1, []int{1, 2}, map[string]bool{"widgets": true, "gadgets": false}},
+		{"two steps", 2, []int{1}, map[string]bool{"widgets": true, "gadgets": false}},
+		{"everything", 10, nil, map[string]bool{"widgets": false, "gadgets": false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			m := newTestMigrator(t, db, testMigrations())
+			ctx := context.Background()
+
+			ran, err := m.Up(ctx)
+			if err != nil || len(ran) != 3 {
+				t.Fatalf("Up = %d migrations, %v", len(ran), err)
+			}
+			if err := m.EnsureCurrent(ctx); err != nil {
+				t.Fatalf("EnsureCurrent after Up = %v", err)
+			}
+			if tt.downSteps > 0 {
+				if _, err := m.Down(ctx, tt.downSteps); err != nil {
+					t.Fatalf("Down = %v", err)
+				}
+			}
+
+			if got := appliedVersions(t, m); !slices.Equal(got, tt.wantApplied) {
+				t.Fatalf("applied = %v, want %v", got, tt.wantApplied)
+			}
+			for table, want := range tt.wantTables {
+				if got := db.Migrator().HasTable(table); got != want {
+					t.Errorf("table %s exists = %v, want %v", table, got, want)
+				}
+			}
+			wantColor := len(tt.wantApplied) >= 2
+			if got := db.Migrator().HasColumn(&widgetV2{}, "color"); db.Migrator().HasTable("widgets") && got != wantColor {
+				t.Errorf("widgets.color exists = %v, want %v", got, wantColor)
+			}
+
+			// ย้อนแล้วต้องรันขึ้นใหม่ได้จนครบ
+			if _, err := m.Up(ctx); err != nil {
+				t.Fatalf("Up again = %v", err)
+			}
+			if got := appliedVersions(t, m); !slices.Equal(got, []int{1, 2, 3}) {
+				t.Fatalf("applied after re-Up = %v", got)
+			}
+		})
+	}
+}
+
+func TestUpAdoptsExistingTables(t *testing.T) {
+	db := openTestDB(t)
+	// ตารางที่เคยสร้างด้วย AutoMigrate ก่อนมี migration พร้อมข้อมูล
+	if err := db.AutoMigrate(&widgetV1{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&widgetV1{Name: "old"})
+
+	m := newTestMigrator(t, db, testMigrations())
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var color string
+	if err := db.Raw("SELECT color FROM widgets WHERE name = ?", "old").Scan(&color).Error; err != nil {
+		t.Fatal(err)
+	}
+	if color != "red" {
+		t.Fatalf("existing row color = %q, want default red", color)
+	}
+}
+
+func TestEnsureCurrent(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied int // จำนวน migration ที่รันไว้ด้วยชุดเต็ม
+		known   int // จำนวน migration ที่โค้ดตัวที่เช็ครู้จัก
+		want    error
+	}{
+		{"current", 3, 3, nil},
+		{"pending", 2, 3, ErrSchemaOutdated},
+		{"too new", 3, 2, ErrSchemaTooNew},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			ctx := context.Background()
+			if _, err := newTestMigrator(t, db, testMigrations()[:tt.applied]).Up(ctx); err != nil {
+				t.Fatal(err)
+			}
+			err := newTestMigrator(t, db, testMigrations()[:tt.known]).EnsureCurrent(ctx)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("EnsureCurrent = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailedMigrationIsNotRecorded(t *testing.T) {
+	db := openTestDB(t)
+	migrations := append(testMigrations(), Migration{
+		Version: 4,
+		Name:    "broken",
+		Up:      func(tx *gorm.DB) error { return errors.New("boom") },
+	})
+	m := newTestMigrator(t, db, migrations)
+
+	ran, err := m.Up(context.Background())
+	if err == nil {
+		t.Fatal("Up succeeded, want error")
+	}
+	if len(ran) != 3 {
+		t.Fatalf("ran %d migrations before failing, want 3", len(ran))
+	}
+	if got := appliedVersions(t, m); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("applied = %v", got)
+	}
+}
+
+func TestDownWithoutRollback(t *testing.T) {
+	db := openTestDB(t)
+	migrations := testMigrations()
+	migrations[2].Down = nil
+	m := newTestMigrator(t, db, migrations)
+	ctx := context.Background()
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Down(ctx, 1); err == nil {
+		t.Fatal("Down succeeded on an irreversible migration")
+	}
+	if got := appliedVersions(t, m); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("applied = %v, want all still applied", got)
+	}
+}
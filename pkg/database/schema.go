@@ -0,0 +1,103 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// helper สำหรับเขียน migration ทีละขั้น (ใช้ได้ทั้ง MySQL และ SQLite)
+// model คือ struct ที่ freeze ไว้ใน migration นั้นๆ ส่วน column / index อ้างด้วยชื่อใน DB
+// ทุกตัวข้ามของที่มีอยู่แล้ว เพื่อรับ DB เดิมที่เคยสร้างด้วย AutoMigrate มาต่อได้
+
+// CreateTable : สร้างตารางถ้ายังไม่มี
+func CreateTable(tx *gorm.DB, models ...any) error {
+	for _, model := range models {
+		if tx.Migrator().HasTable(model) {
+			continue
+		}
+		if err := tx.Migrator().CreateTable(model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddColumns : เพิ่ม column ตามนิยามใน model
+func AddColumns(tx *gorm.DB, model any, columns ...string) error {
+	for _, col := range columns {
+		if tx.Migrator().HasColumn(model, col) {
+			continue
+		}
+		if err := tx.Migrator().AddColumn(model, col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropColumns : ลบ column (ต้องลบ index ที่ใช้ column นั้นก่อน)
+func DropColumns(tx *gorm.DB, model any, columns ...string) error {
+	for _, col := range columns {
+		if !tx.Migrator().HasColumn(model, col) {
+			continue
+		}
+		var err error
+		if isSQLite(tx) {
+			// Migrator ของ SQLite สร้างตารางใหม่ทั้งตัวแล้ว index อื่นหายหมด ใช้ DROP COLUMN ตรงๆ แทน
+			stmt := &gorm.Statement{DB: tx}
+			if err = stmt.Parse(model); err == nil {
+				err = tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: stmt.Table}, clause.Column{Name: col}).Error
+			}
+		} else {
+			err = tx.Migrator().DropColumn(model, col)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AlterColumns : เปลี่ยนชนิดของ column ให้ตรงกับนิยามใน model
+// SQLite ไม่สนขนาด / ชนิดของ column อยู่แล้ว (และ Migrator จะสร้างตารางใหม่จน index หาย) จึงข้ามไป
+func AlterColumns(tx *gorm.DB, model any, columns ...string) error {
+	if isSQLite(tx) {
+		return nil
+	}
+	for _, col := range columns {
+		if err := tx.Migrator().AlterColumn(model, col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateIndexes : สร้าง index ตามชื่อที่ประกาศไว้ใน tag ของ model
+func CreateIndexes(tx *gorm.DB, model any, names ...string) error {
+	for _, name := range names {
+		if tx.Migrator().HasIndex(model, name) {
+			continue
+		}
+		if err := tx.Migrator().CreateIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropIndexes : ลบ index (ไม่มีอยู่แล้วข้าม)
+func DropIndexes(tx *gorm.DB, model any, names ...string) error {
+	for _, name := range names {
+		if !tx.Migrator().HasIndex(model, name) {
+			continue
+		}
+		if err := tx.Migrator().DropIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSQLite(tx *gorm.DB) bool {
+	return tx.Dialector.Name() == string(DriverSQLite)
+}
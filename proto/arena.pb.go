@@ -0,0 +1,997 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: proto/arena.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DuelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fighter1Id    string                 `protobuf:"bytes,1,opt,name=fighter1_id,json=fighter1Id,proto3" json:"fighter1_id,omitempty"`
+	Fighter2Id    string                 `protobuf:"bytes,2,opt,name=fighter2_id,json=fighter2Id,proto3" json:"fighter2_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuelRequest) Reset() {
+	*x = DuelRequest{}
+	mi := &file_proto_arena_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuelRequest) ProtoMessage() {}
+
+func (x *DuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuelRequest.ProtoReflect.Descriptor instead.
+func (*DuelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DuelRequest) GetFighter1Id() string {
+	if x != nil {
+		return x.Fighter1Id
+	}
+	return ""
+}
+
+func (x *DuelRequest) GetFighter2Id() string {
+	if x != nil {
+		return x.Fighter2Id
+	}
+	return ""
+}
+
+type BattleEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // match_start, initiative, turn_start, hit, miss, knockout, turn_limit
+	Turn          int32                  `protobuf:"varint,2,opt,name=turn,proto3" json:"turn,omitempty"`
+	AttackerId    string                 `protobuf:"bytes,3,opt,name=attacker_id,json=attackerId,proto3" json:"attacker_id,omitempty"`
+	AttackerName  string                 `protobuf:"bytes,4,opt,name=attacker_name,json=attackerName,proto3" json:"attacker_name,omitempty"`
+	DefenderId    string                 `protobuf:"bytes,5,opt,name=defender_id,json=defenderId,proto3" json:"defender_id,omitempty"`
+	DefenderName  string                 `protobuf:"bytes,6,opt,name=defender_name,json=defenderName,proto3" json:"defender_name,omitempty"`
+	Damage        int32                  `protobuf:"varint,7,opt,name=damage,proto3" json:"damage,omitempty"`
+	AttackerHp    int32                  `protobuf:"varint,8,opt,name=attacker_hp,json=attackerHp,proto3" json:"attacker_hp,omitempty"`
+	DefenderHp    int32                  `protobuf:"varint,9,opt,name=defender_hp,json=defenderHp,proto3" json:"defender_hp,omitempty"`
+	Text          string                 `protobuf:"bytes,10,opt,name=text,proto3" json:"text,omitempty"` // ข้อความให้คนอ่าน
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleEvent) Reset() {
+	*x = BattleEvent{}
+	mi := &file_proto_arena_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleEvent) ProtoMessage() {}
+
+func (x *BattleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleEvent.ProtoReflect.Descriptor instead.
+func (*BattleEvent) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BattleEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BattleEvent) GetTurn() int32 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+func (x *BattleEvent) GetAttackerId() string {
+	if x != nil {
+		return x.AttackerId
+	}
+	return ""
+}
+
+func (x *BattleEvent) GetAttackerName() string {
+	if x != nil {
+		return x.AttackerName
+	}
+	return ""
+}
+
+func (x *BattleEvent) GetDefenderId() string {
+	if x != nil {
+		return x.DefenderId
+	}
+	return ""
+}
+
+func (x *BattleEvent) GetDefenderName() string {
+	if x != nil {
+		return x.DefenderName
+	}
+	return ""
+}
+
+func (x *BattleEvent) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *BattleEvent) GetAttackerHp() int32 {
+	if x != nil {
+		return x.AttackerHp
+	}
+	return 0
+}
+
+func (x *BattleEvent) GetDefenderHp() int32 {
+	if x != nil {
+		return x.DefenderHp
+	}
+	return 0
+}
+
+func (x *BattleEvent) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type BattleResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SeriesId     uint64                 `protobuf:"varint,2,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`             // 0 = ไม่ได้อยู่ในซีรีส์
+	TournamentId uint64                 `protobuf:"varint,3,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"` // 0 = ไม่ได้อยู่ในทัวร์นาเมนต์
+	Fighter1Id   string                 `protobuf:"bytes,4,opt,name=fighter1_id,json=fighter1Id,proto3" json:"fighter1_id,omitempty"`
+	Fighter1Name string                 `protobuf:"bytes,5,opt,name=fighter1_name,json=fighter1Name,proto3" json:"fighter1_name,omitempty"`
+	Fighter2Id   string                 `protobuf:"bytes,6,opt,name=fighter2_id,json=fighter2Id,proto3" json:"fighter2_id,omitempty"`
+	Fighter2Name string                 `protobuf:"bytes,7,opt,name=fighter2_name,json=fighter2Name,proto3" json:"fighter2_name,omitempty"`
+	Result       string                 `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"` // win | draw
+	WinnerId     string                 `protobuf:"bytes,9,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	Winner       string                 `protobuf:"bytes,10,opt,name=winner,proto3" json:"winner,omitempty"`
+	Turns        int32                  `protobuf:"varint,11,opt,name=turns,proto3" json:"turns,omitempty"`
+	Seed         int64                  `protobuf:"varint,12,opt,name=seed,proto3" json:"seed,omitempty"`
+	Events       []*BattleEvent         `protobuf:"bytes,13,rep,name=events,proto3" json:"events,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// ค่าสถานะตอนลงดวล (ไม่มี = battle เก่าก่อนเริ่มเก็บ)
+	Fighter1Stats   *FighterSnapshot `protobuf:"bytes,15,opt,name=fighter1_stats,json=fighter1Stats,proto3" json:"fighter1_stats,omitempty"`
+	Fighter2Stats   *FighterSnapshot `protobuf:"bytes,16,opt,name=fighter2_stats,json=fighter2Stats,proto3" json:"fighter2_stats,omitempty"`
+	MaxTurns        int32            `protobuf:"varint,17,opt,name=max_turns,json=maxTurns,proto3" json:"max_turns,omitempty"`                      // กติกาที่ใช้ดวล ใช้คู่กับ seed เพื่อ replay (0 = battle เก่าก่อนเริ่มเก็บ)
+	TournamentRound int32            `protobuf:"varint,18,opt,name=tournament_round,json=tournamentRound,proto3" json:"tournament_round,omitempty"` // รอบในทัวร์นาเมนต์ (0 = ไม่ได้อยู่ในทัวร์นาเมนต์)
+	// เวลาที่ถูกแทนด้วยการเล่นรอบทัวร์นาเมนต์ใหม่ (ไม่มี = ยังนับอยู่)
+	SupersededAt  *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=superseded_at,json=supersededAt,proto3" json:"superseded_at,omitempty"`
+	Logs          []string               `protobuf:"bytes,20,rep,name=logs,proto3" json:"logs,omitempty"` // ข้อความที่ render จาก events (เหมือน logs ใน HTTP)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleResult) Reset() {
+	*x = BattleResult{}
+	mi := &file_proto_arena_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleResult) ProtoMessage() {}
+
+func (x *BattleResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleResult.ProtoReflect.Descriptor instead.
+func (*BattleResult) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BattleResult) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BattleResult) GetSeriesId() uint64 {
+	if x != nil {
+		return x.SeriesId
+	}
+	return 0
+}
+
+func (x *BattleResult) GetTournamentId() uint64 {
+	if x != nil {
+		return x.TournamentId
+	}
+	return 0
+}
+
+func (x *BattleResult) GetFighter1Id() string {
+	if x != nil {
+		return x.Fighter1Id
+	}
+	return ""
+}
+
+func (x *BattleResult) GetFighter1Name() string {
+	if x != nil {
+		return x.Fighter1Name
+	}
+	return ""
+}
+
+func (x *BattleResult) GetFighter2Id() string {
+	if x != nil {
+		return x.Fighter2Id
+	}
+	return ""
+}
+
+func (x *BattleResult) GetFighter2Name() string {
+	if x != nil {
+		return x.Fighter2Name
+	}
+	return ""
+}
+
+func (x *BattleResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *BattleResult) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *BattleResult) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *BattleResult) GetTurns() int32 {
+	if x != nil {
+		return x.Turns
+	}
+	return 0
+}
+
+func (x *BattleResult) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *BattleResult) GetEvents() []*BattleEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *BattleResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BattleResult) GetFighter1Stats() *FighterSnapshot {
+	if x != nil {
+		return x.Fighter1Stats
+	}
+	return nil
+}
+
+func (x *BattleResult) GetFighter2Stats() *FighterSnapshot {
+	if x != nil {
+		return x.Fighter2Stats
+	}
+	return nil
+}
+
+func (x *BattleResult) GetMaxTurns() int32 {
+	if x != nil {
+		return x.MaxTurns
+	}
+	return 0
+}
+
+func (x *BattleResult) GetTournamentRound() int32 {
+	if x != nil {
+		return x.TournamentRound
+	}
+	return 0
+}
+
+func (x *BattleResult) GetSupersededAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SupersededAt
+	}
+	return nil
+}
+
+func (x *BattleResult) GetLogs() []string {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type FighterSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Health        int32                  `protobuf:"varint,1,opt,name=health,proto3" json:"health,omitempty"`
+	Damage        int32                  `protobuf:"varint,2,opt,name=damage,proto3" json:"damage,omitempty"`
+	Speed         int32                  `protobuf:"varint,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	Accuracy      float64                `protobuf:"fixed64,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Version       int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FighterSnapshot) Reset() {
+	*x = FighterSnapshot{}
+	mi := &file_proto_arena_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FighterSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FighterSnapshot) ProtoMessage() {}
+
+func (x *FighterSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FighterSnapshot.ProtoReflect.Descriptor instead.
+func (*FighterSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FighterSnapshot) GetHealth() int32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *FighterSnapshot) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *FighterSnapshot) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *FighterSnapshot) GetAccuracy() float64 {
+	if x != nil {
+		return x.Accuracy
+	}
+	return 0
+}
+
+func (x *FighterSnapshot) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                            // 0 = ใช้ค่า default (สูงสุด 100)
+	FighterId     string                 `protobuf:"bytes,2,opt,name=fighter_id,json=fighterId,proto3" json:"fighter_id,omitempty"`    // ว่าง = ทุกคน
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`                           // next_cursor ของหน้าก่อน
+	OpponentId    string                 `protobuf:"bytes,4,opt,name=opponent_id,json=opponentId,proto3" json:"opponent_id,omitempty"` // ใช้คู่กับ fighter_id = เฉพาะ battle ระหว่างสองคนนี้
+	WinnerId      string                 `protobuf:"bytes,5,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	TournamentId  uint64                 `protobuf:"varint,6,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	SeriesId      uint64                 `protobuf:"varint,7,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"`  // created_at >= from
+	To            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=to,proto3" json:"to,omitempty"`      // created_at < to
+	Sort          string                 `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"` // newest (default), oldest, longest, shortest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_proto_arena_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetFighterId() string {
+	if x != nil {
+		return x.FighterId
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetOpponentId() string {
+	if x != nil {
+		return x.OpponentId
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetTournamentId() uint64 {
+	if x != nil {
+		return x.TournamentId
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetSeriesId() uint64 {
+	if x != nil {
+		return x.SeriesId
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Battles       []*BattleResult        `protobuf:"bytes,1,rep,name=battles,proto3" json:"battles,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // ว่าง = หน้าสุดท้ายแล้ว
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_proto_arena_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetHistoryResponse) GetBattles() []*BattleResult {
+	if x != nil {
+		return x.Battles
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetBattleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBattleRequest) Reset() {
+	*x = GetBattleRequest{}
+	mi := &file_proto_arena_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBattleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBattleRequest) ProtoMessage() {}
+
+func (x *GetBattleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBattleRequest.ProtoReflect.Descriptor instead.
+func (*GetBattleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBattleRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type StreamDuelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fighter1Id    string                 `protobuf:"bytes,1,opt,name=fighter1_id,json=fighter1Id,proto3" json:"fighter1_id,omitempty"`
+	Fighter2Id    string                 `protobuf:"bytes,2,opt,name=fighter2_id,json=fighter2Id,proto3" json:"fighter2_id,omitempty"`
+	PaceMs        *int32                 `protobuf:"varint,3,opt,name=pace_ms,json=paceMs,proto3,oneof" json:"pace_ms,omitempty"` // ไม่ส่ง = ค่า default, 0 = ไม่เว้นจังหวะ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamDuelRequest) Reset() {
+	*x = StreamDuelRequest{}
+	mi := &file_proto_arena_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDuelRequest) ProtoMessage() {}
+
+func (x *StreamDuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDuelRequest.ProtoReflect.Descriptor instead.
+func (*StreamDuelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamDuelRequest) GetFighter1Id() string {
+	if x != nil {
+		return x.Fighter1Id
+	}
+	return ""
+}
+
+func (x *StreamDuelRequest) GetFighter2Id() string {
+	if x != nil {
+		return x.Fighter2Id
+	}
+	return ""
+}
+
+func (x *StreamDuelRequest) GetPaceMs() int32 {
+	if x != nil && x.PaceMs != nil {
+		return *x.PaceMs
+	}
+	return 0
+}
+
+type DuelUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*DuelUpdate_Event
+	//	*DuelUpdate_Result
+	Update        isDuelUpdate_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuelUpdate) Reset() {
+	*x = DuelUpdate{}
+	mi := &file_proto_arena_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuelUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuelUpdate) ProtoMessage() {}
+
+func (x *DuelUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_arena_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuelUpdate.ProtoReflect.Descriptor instead.
+func (*DuelUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_arena_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DuelUpdate) GetUpdate() isDuelUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *DuelUpdate) GetEvent() *BattleEvent {
+	if x != nil {
+		if x, ok := x.Update.(*DuelUpdate_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *DuelUpdate) GetResult() *BattleResult {
+	if x != nil {
+		if x, ok := x.Update.(*DuelUpdate_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isDuelUpdate_Update interface {
+	isDuelUpdate_Update()
+}
+
+type DuelUpdate_Event struct {
+	Event *BattleEvent `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type DuelUpdate_Result struct {
+	Result *BattleResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"` // ข้อความสุดท้ายของ stream
+}
+
+func (*DuelUpdate_Event) isDuelUpdate_Update() {}
+
+func (*DuelUpdate_Result) isDuelUpdate_Update() {}
+
+var File_proto_arena_proto protoreflect.FileDescriptor
+
+const file_proto_arena_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/arena.proto\x12\x05arena\x1a\x1fgoogle/protobuf/timestamp.proto\"O\n" +
+	"\vDuelRequest\x12\x1f\n" +
+	"\vfighter1_id\x18\x01 \x01(\tR\n" +
+	"fighter1Id\x12\x1f\n" +
+	"\vfighter2_id\x18\x02 \x01(\tR\n" +
+	"fighter2Id\"\xaf\x02\n" +
+	"\vBattleEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04turn\x18\x02 \x01(\x05R\x04turn\x12\x1f\n" +
+	"\vattacker_id\x18\x03 \x01(\tR\n" +
+	"attackerId\x12#\n" +
+	"\rattacker_name\x18\x04 \x01(\tR\fattackerName\x12\x1f\n" +
+	"\vdefender_id\x18\x05 \x01(\tR\n" +
+	"defenderId\x12#\n" +
+	"\rdefender_name\x18\x06 \x01(\tR\fdefenderName\x12\x16\n" +
+	"\x06damage\x18\a \x01(\x05R\x06damage\x12\x1f\n" +
+	"\vattacker_hp\x18\b \x01(\x05R\n" +
+	"attackerHp\x12\x1f\n" +
+	"\vdefender_hp\x18\t \x01(\x05R\n" +
+	"defenderHp\x12\x12\n" +
+	"\x04text\x18\n" +
+	" \x01(\tR\x04text\"\xe5\x05\n" +
+	"\fBattleResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tseries_id\x18\x02 \x01(\x04R\bseriesId\x12#\n" +
+	"\rtournament_id\x18\x03 \x01(\x04R\ftournamentId\x12\x1f\n" +
+	"\vfighter1_id\x18\x04 \x01(\tR\n" +
+	"fighter1Id\x12#\n" +
+	"\rfighter1_name\x18\x05 \x01(\tR\ffighter1Name\x12\x1f\n" +
+	"\vfighter2_id\x18\x06 \x01(\tR\n" +
+	"fighter2Id\x12#\n" +
+	"\rfighter2_name\x18\a \x01(\tR\ffighter2Name\x12\x16\n" +
+	"\x06result\x18\b \x01(\tR\x06result\x12\x1b\n" +
+	"\twinner_id\x18\t \x01(\tR\bwinnerId\x12\x16\n" +
+	"\x06winner\x18\n" +
+	" \x01(\tR\x06winner\x12\x14\n" +
+	"\x05turns\x18\v \x01(\x05R\x05turns\x12\x12\n" +
+	"\x04seed\x18\f \x01(\x03R\x04seed\x12*\n" +
+	"\x06events\x18\r \x03(\v2\x12.arena.BattleEventR\x06events\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\x0efighter1_stats\x18\x0f \x01(\v2\x16.arena.FighterSnapshotR\rfighter1Stats\x12=\n" +
+	"\x0efighter2_stats\x18\x10 \x01(\v2\x16.arena.FighterSnapshotR\rfighter2Stats\x12\x1b\n" +
+	"\tmax_turns\x18\x11 \x01(\x05R\bmaxTurns\x12)\n" +
+	"\x10tournament_round\x18\x12 \x01(\x05R\x0ftournamentRound\x12?\n" +
+	"\rsuperseded_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\fsupersededAt\x12\x12\n" +
+	"\x04logs\x18\x14 \x03(\tR\x04logs\"\x8d\x01\n" +
+	"\x0fFighterSnapshot\x12\x16\n" +
+	"\x06health\x18\x01 \x01(\x05R\x06health\x12\x16\n" +
+	"\x06damage\x18\x02 \x01(\x05R\x06damage\x12\x14\n" +
+	"\x05speed\x18\x03 \x01(\x05R\x05speed\x12\x1a\n" +
+	"\baccuracy\x18\x04 \x01(\x01R\baccuracy\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\"\xd0\x02\n" +
+	"\x11GetHistoryRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"fighter_id\x18\x02 \x01(\tR\tfighterId\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1f\n" +
+	"\vopponent_id\x18\x04 \x01(\tR\n" +
+	"opponentId\x12\x1b\n" +
+	"\twinner_id\x18\x05 \x01(\tR\bwinnerId\x12#\n" +
+	"\rtournament_id\x18\x06 \x01(\x04R\ftournamentId\x12\x1b\n" +
+	"\tseries_id\x18\a \x01(\x04R\bseriesId\x12.\n" +
+	"\x04from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x12\n" +
+	"\x04sort\x18\n" +
+	" \x01(\tR\x04sort\"d\n" +
+	"\x12GetHistoryResponse\x12-\n" +
+	"\abattles\x18\x01 \x03(\v2\x13.arena.BattleResultR\abattles\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\"\n" +
+	"\x10GetBattleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x7f\n" +
+	"\x11StreamDuelRequest\x12\x1f\n" +
+	"\vfighter1_id\x18\x01 \x01(\tR\n" +
+	"fighter1Id\x12\x1f\n" +
+	"\vfighter2_id\x18\x02 \x01(\tR\n" +
+	"fighter2Id\x12\x1c\n" +
+	"\apace_ms\x18\x03 \x01(\x05H\x00R\x06paceMs\x88\x01\x01B\n" +
+	"\n" +
+	"\b_pace_ms\"q\n" +
+	"\n" +
+	"DuelUpdate\x12*\n" +
+	"\x05event\x18\x01 \x01(\v2\x12.arena.BattleEventH\x00R\x05event\x12-\n" +
+	"\x06result\x18\x02 \x01(\v2\x13.arena.BattleResultH\x00R\x06resultB\b\n" +
+	"\x06update2\xfa\x01\n" +
+	"\fArenaService\x12/\n" +
+	"\x04Duel\x12\x12.arena.DuelRequest\x1a\x13.arena.BattleResult\x12A\n" +
+	"\n" +
+	"GetHistory\x12\x18.arena.GetHistoryRequest\x1a\x19.arena.GetHistoryResponse\x129\n" +
+	"\tGetBattle\x12\x17.arena.GetBattleRequest\x1a\x13.arena.BattleResult\x12;\n" +
+	"\n" +
+	"StreamDuel\x12\x18.arena.StreamDuelRequest\x1a\x11.arena.DuelUpdate0\x01B,Z*github.com/yourusername/cowboy_arena/protob\x06proto3"
+
+var (
+	file_proto_arena_proto_rawDescOnce sync.Once
+	file_proto_arena_proto_rawDescData []byte
+)
+
+func file_proto_arena_proto_rawDescGZIP() []byte {
+	file_proto_arena_proto_rawDescOnce.Do(func() {
+		file_proto_arena_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_arena_proto_rawDesc), len(file_proto_arena_proto_rawDesc)))
+	})
+	return file_proto_arena_proto_rawDescData
+}
+
+var file_proto_arena_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_arena_proto_goTypes = []any{
+	(*DuelRequest)(nil),           // 0: arena.DuelRequest
+	(*BattleEvent)(nil),           // 1: arena.BattleEvent
+	(*BattleResult)(nil),          // 2: arena.BattleResult
+	(*FighterSnapshot)(nil),       // 3: arena.FighterSnapshot
+	(*GetHistoryRequest)(nil),     // 4: arena.GetHistoryRequest
+	(*GetHistoryResponse)(nil),    // 5: arena.GetHistoryResponse
+	(*GetBattleRequest)(nil),      // 6: arena.GetBattleRequest
+	(*StreamDuelRequest)(nil),     // 7: arena.StreamDuelRequest
+	(*DuelUpdate)(nil),            // 8: arena.DuelUpdate
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_proto_arena_proto_depIdxs = []int32{
+	1,  // 0: arena.BattleResult.events:type_name -> arena.BattleEvent
+	9,  // 1: arena.BattleResult.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: arena.BattleResult.fighter1_stats:type_name -> arena.FighterSnapshot
+	3,  // 3: arena.BattleResult.fighter2_stats:type_name -> arena.FighterSnapshot
+	9,  // 4: arena.BattleResult.superseded_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: arena.GetHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	9,  // 6: arena.GetHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 7: arena.GetHistoryResponse.battles:type_name -> arena.BattleResult
+	1,  // 8: arena.DuelUpdate.event:type_name -> arena.BattleEvent
+	2,  // 9: arena.DuelUpdate.result:type_name -> arena.BattleResult
+	0,  // 10: arena.ArenaService.Duel:input_type -> arena.DuelRequest
+	4,  // 11: arena.ArenaService.GetHistory:input_type -> arena.GetHistoryRequest
+	6,  // 12: arena.ArenaService.GetBattle:input_type -> arena.GetBattleRequest
+	7,  // 13: arena.ArenaService.StreamDuel:input_type -> arena.StreamDuelRequest
+	2,  // 14: arena.ArenaService.Duel:output_type -> arena.BattleResult
+	5,  // 15: arena.ArenaService.GetHistory:output_type -> arena.GetHistoryResponse
+	2,  // 16: arena.ArenaService.GetBattle:output_type -> arena.BattleResult
+	8,  // 17: arena.ArenaService.StreamDuel:output_type -> arena.DuelUpdate
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_proto_arena_proto_init() }
+func file_proto_arena_proto_init() {
+	if File_proto_arena_proto != nil {
+		return
+	}
+	file_proto_arena_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_arena_proto_msgTypes[8].OneofWrappers = []any{
+		(*DuelUpdate_Event)(nil),
+		(*DuelUpdate_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_arena_proto_rawDesc), len(file_proto_arena_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_arena_proto_goTypes,
+		DependencyIndexes: file_proto_arena_proto_depIdxs,
+		MessageInfos:      file_proto_arena_proto_msgTypes,
+	}.Build()
+	File_proto_arena_proto = out.File
+	file_proto_arena_proto_goTypes = nil
+	file_proto_arena_proto_depIdxs = nil
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: proto/arena.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ArenaService_Duel_FullMethodName       = "/arena.ArenaService/Duel"
+	ArenaService_GetHistory_FullMethodName = "/arena.ArenaService/GetHistory"
+	ArenaService_GetBattle_FullMethodName  = "/arena.ArenaService/GetBattle"
+	ArenaService_StreamDuel_FullMethodName = "/arena.ArenaService/StreamDuel"
+)
+
+// ArenaServiceClient is the client API for ArenaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ArenaServiceClient interface {
+	// ดวล 1 ครั้งแล้วบันทึกผล (เหมือน POST /duel)
+	Duel(ctx context.Context, in *DuelRequest, opts ...grpc.CallOption) (*BattleResult, error)
+	// ประวัติการดวลทีละหน้า พร้อมตัวกรอง (เหมือน GET /history)
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// ดึงผลการดวลตาม ID (เหมือน GET /battles/{id})
+	GetBattle(ctx context.Context, in *GetBattleRequest, opts ...grpc.CallOption) (*BattleResult, error)
+	// ถ่ายทอดสดทีละจังหวะ ข้อความสุดท้ายคือผลที่บันทึกแล้ว (เหมือน GET /duel/stream)
+	StreamDuel(ctx context.Context, in *StreamDuelRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DuelUpdate], error)
+}
+
+type arenaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArenaServiceClient(cc grpc.ClientConnInterface) ArenaServiceClient {
+	return &arenaServiceClient{cc}
+}
+
+func (c *arenaServiceClient) Duel(ctx context.Context, in *DuelRequest, opts ...grpc.CallOption) (*BattleResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BattleResult)
+	err := c.cc.Invoke(ctx, ArenaService_Duel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arenaServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, ArenaService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arenaServiceClient) GetBattle(ctx context.Context, in *GetBattleRequest, opts ...grpc.CallOption) (*BattleResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BattleResult)
+	err := c.cc.Invoke(ctx, ArenaService_GetBattle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arenaServiceClient) StreamDuel(ctx context.Context, in *StreamDuelRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DuelUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArenaService_ServiceDesc.Streams[0], ArenaService_StreamDuel_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamDuelRequest, DuelUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArenaService_StreamDuelClient = grpc.ServerStreamingClient[DuelUpdate]
+
+// ArenaServiceServer is the server API for ArenaService service.
+// All implementations must embed UnimplementedArenaServiceServer
+// for forward compatibility.
+type ArenaServiceServer interface {
+	// ดวล 1 ครั้งแล้วบันทึกผล (เหมือน POST /duel)
+	Duel(context.Context, *DuelRequest) (*BattleResult, error)
+	// ประวัติการดวลทีละหน้า พร้อมตัวกรอง (เหมือน GET /history)
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// ดึงผลการดวลตาม ID (เหมือน GET /battles/{id})
+	GetBattle(context.Context, *GetBattleRequest) (*BattleResult, error)
+	// ถ่ายทอดสดทีละจังหวะ ข้อความสุดท้ายคือผลที่บันทึกแล้ว (เหมือน GET /duel/stream)
+	StreamDuel(*StreamDuelRequest, grpc.ServerStreamingServer[DuelUpdate]) error
+	mustEmbedUnimplementedArenaServiceServer()
+}
+
+// UnimplementedArenaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArenaServiceServer struct{}
+
+func (UnimplementedArenaServiceServer) Duel(context.Context, *DuelRequest) (*BattleResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method Duel not implemented")
+}
+func (UnimplementedArenaServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedArenaServiceServer) GetBattle(context.Context, *GetBattleRequest) (*BattleResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBattle not implemented")
+}
+func (UnimplementedArenaServiceServer) StreamDuel(*StreamDuelRequest, grpc.ServerStreamingServer[DuelUpdate]) error {
+	return status.Error(codes.Unimplemented, "method StreamDuel not implemented")
+}
+func (UnimplementedArenaServiceServer) mustEmbedUnimplementedArenaServiceServer() {}
+func (UnimplementedArenaServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeArenaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArenaServiceServer will
+// result in compilation errors.
+type UnsafeArenaServiceServer interface {
+	mustEmbedUnimplementedArenaServiceServer()
+}
+
+func RegisterArenaServiceServer(s grpc.ServiceRegistrar, srv ArenaServiceServer) {
+	// If the following call panics, it indicates UnimplementedArenaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ArenaService_ServiceDesc, srv)
+}
+
+func _ArenaService_Duel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServiceServer).Duel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArenaService_Duel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServiceServer).Duel(ctx, req.(*DuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArenaService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArenaService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArenaService_GetBattle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBattleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServiceServer).GetBattle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArenaService_GetBattle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServiceServer).GetBattle(ctx, req.(*GetBattleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArenaService_StreamDuel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamDuelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArenaServiceServer).StreamDuel(m, &grpc.GenericServerStream[StreamDuelRequest, DuelUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArenaService_StreamDuelServer = grpc.ServerStreamingServer[DuelUpdate]
+
+// ArenaService_ServiceDesc is the grpc.ServiceDesc for ArenaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArenaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "arena.ArenaService",
+	HandlerType: (*ArenaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Duel",
+			Handler:    _ArenaService_Duel_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _ArenaService_GetHistory_Handler,
+		},
+		{
+			MethodName: "GetBattle",
+			Handler:    _ArenaService_GetBattle_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamDuel",
+			Handler:       _ArenaService_StreamDuel_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/arena.proto",
+}
@@ -9,6 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +23,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CowboyEvent_Type int32
+
+const (
+	CowboyEvent_TYPE_UNSPECIFIED CowboyEvent_Type = 0
+	CowboyEvent_CREATED          CowboyEvent_Type = 1
+	CowboyEvent_UPDATED          CowboyEvent_Type = 2
+	CowboyEvent_DELETED          CowboyEvent_Type = 3
+)
+
+// Enum value maps for CowboyEvent_Type.
+var (
+	CowboyEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DELETED",
+	}
+	CowboyEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"CREATED":          1,
+		"UPDATED":          2,
+		"DELETED":          3,
+	}
+)
+
+func (x CowboyEvent_Type) Enum() *CowboyEvent_Type {
+	p := new(CowboyEvent_Type)
+	*p = x
+	return p
+}
+
+func (x CowboyEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CowboyEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_duelist_proto_enumTypes[0].Descriptor()
+}
+
+func (CowboyEvent_Type) Type() protoreflect.EnumType {
+	return &file_proto_duelist_proto_enumTypes[0]
+}
+
+func (x CowboyEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CowboyEvent_Type.Descriptor instead.
+func (CowboyEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{11, 0}
+}
+
 type CowboyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -29,6 +83,7 @@ type CowboyResponse struct {
 	Damage        int32                  `protobuf:"varint,4,opt,name=damage,proto3" json:"damage,omitempty"`
 	Speed         int32                  `protobuf:"varint,5,opt,name=speed,proto3" json:"speed,omitempty"`
 	Accuracy      float64                `protobuf:"fixed64,6,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Version       int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -105,6 +160,13 @@ func (x *CowboyResponse) GetAccuracy() float64 {
 	return 0
 }
 
+func (x *CowboyResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CreateCowboyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -190,8 +252,15 @@ func (x *CreateCowboyRequest) GetAccuracy() float64 {
 }
 
 type GetCowboyRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ไม่ระบุ = ค่าปัจจุบัน
+	//
+	// Types that are valid to be assigned to At:
+	//
+	//	*GetCowboyRequest_Version
+	//	*GetCowboyRequest_AsOf
+	At            isGetCowboyRequest_At `protobuf_oneof:"at"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -233,30 +302,799 @@ func (x *GetCowboyRequest) GetId() string {
 	return ""
 }
 
+func (x *GetCowboyRequest) GetAt() isGetCowboyRequest_At {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *GetCowboyRequest) GetVersion() int32 {
+	if x != nil {
+		if x, ok := x.At.(*GetCowboyRequest_Version); ok {
+			return x.Version
+		}
+	}
+	return 0
+}
+
+func (x *GetCowboyRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.At.(*GetCowboyRequest_AsOf); ok {
+			return x.AsOf
+		}
+	}
+	return nil
+}
+
+type isGetCowboyRequest_At interface {
+	isGetCowboyRequest_At()
+}
+
+type GetCowboyRequest_Version struct {
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3,oneof"`
+}
+
+type GetCowboyRequest_AsOf struct {
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3,oneof"` // ค่า ณ เวลานั้น
+}
+
+func (*GetCowboyRequest_Version) isGetCowboyRequest_At() {}
+
+func (*GetCowboyRequest_AsOf) isGetCowboyRequest_At() {}
+
+type BatchGetCowboysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // สูงสุด 100 ตัวต่อครั้ง (ID ซ้ำจะถูกรวมเป็นตัวเดียว)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCowboysRequest) Reset() {
+	*x = BatchGetCowboysRequest{}
+	mi := &file_proto_duelist_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCowboysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCowboysRequest) ProtoMessage() {}
+
+func (x *BatchGetCowboysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCowboysRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetCowboysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetCowboysRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetCowboysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cowboys       []*CowboyResponse      `protobuf:"bytes,1,rep,name=cowboys,proto3" json:"cowboys,omitempty"` // เรียงตามลำดับใน ids
+	MissingIds    []string               `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCowboysResponse) Reset() {
+	*x = BatchGetCowboysResponse{}
+	mi := &file_proto_duelist_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCowboysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCowboysResponse) ProtoMessage() {}
+
+func (x *BatchGetCowboysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCowboysResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetCowboysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetCowboysResponse) GetCowboys() []*CowboyResponse {
+	if x != nil {
+		return x.Cowboys
+	}
+	return nil
+}
+
+func (x *BatchGetCowboysResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type UpdateCowboyRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Health   int32                  `protobuf:"varint,3,opt,name=health,proto3" json:"health,omitempty"`
+	Damage   int32                  `protobuf:"varint,4,opt,name=damage,proto3" json:"damage,omitempty"`
+	Speed    int32                  `protobuf:"varint,5,opt,name=speed,proto3" json:"speed,omitempty"`
+	Accuracy float64                `protobuf:"fixed64,6,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	// path ที่ใช้ได้: name, health, damage, speed, accuracy
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCowboyRequest) Reset() {
+	*x = UpdateCowboyRequest{}
+	mi := &file_proto_duelist_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCowboyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCowboyRequest) ProtoMessage() {}
+
+func (x *UpdateCowboyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCowboyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCowboyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCowboyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCowboyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCowboyRequest) GetHealth() int32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *UpdateCowboyRequest) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *UpdateCowboyRequest) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *UpdateCowboyRequest) GetAccuracy() float64 {
+	if x != nil {
+		return x.Accuracy
+	}
+	return 0
+}
+
+func (x *UpdateCowboyRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteCowboyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCowboyRequest) Reset() {
+	*x = DeleteCowboyRequest{}
+	mi := &file_proto_duelist_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCowboyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCowboyRequest) ProtoMessage() {}
+
+func (x *DeleteCowboyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCowboyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCowboyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteCowboyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCowboyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCowboyResponse) Reset() {
+	*x = DeleteCowboyResponse{}
+	mi := &file_proto_duelist_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCowboyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCowboyResponse) ProtoMessage() {}
+
+func (x *DeleteCowboyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCowboyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCowboyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{7}
+}
+
+type ListCowboysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // 0 = ใช้ค่า default
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`    // ได้มาจาก next_page_token ของหน้าก่อน
+	NameFilter    string                 `protobuf:"bytes,3,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"` // ค้นหาจากชื่อ (บางส่วนของชื่อก็ได้)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCowboysRequest) Reset() {
+	*x = ListCowboysRequest{}
+	mi := &file_proto_duelist_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCowboysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCowboysRequest) ProtoMessage() {}
+
+func (x *ListCowboysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCowboysRequest.ProtoReflect.Descriptor instead.
+func (*ListCowboysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCowboysRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCowboysRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCowboysRequest) GetNameFilter() string {
+	if x != nil {
+		return x.NameFilter
+	}
+	return ""
+}
+
+type ListCowboysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cowboys       []*CowboyResponse      `protobuf:"bytes,1,rep,name=cowboys,proto3" json:"cowboys,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // ว่าง = หน้าสุดท้ายแล้ว
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCowboysResponse) Reset() {
+	*x = ListCowboysResponse{}
+	mi := &file_proto_duelist_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCowboysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCowboysResponse) ProtoMessage() {}
+
+func (x *ListCowboysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCowboysResponse.ProtoReflect.Descriptor instead.
+func (*ListCowboysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCowboysResponse) GetCowboys() []*CowboyResponse {
+	if x != nil {
+		return x.Cowboys
+	}
+	return nil
+}
+
+func (x *ListCowboysResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchCowboysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCowboysRequest) Reset() {
+	*x = WatchCowboysRequest{}
+	mi := &file_proto_duelist_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCowboysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCowboysRequest) ProtoMessage() {}
+
+func (x *WatchCowboysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCowboysRequest.ProtoReflect.Descriptor instead.
+func (*WatchCowboysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{10}
+}
+
+type CowboyEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          CowboyEvent_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=duelist.CowboyEvent_Type" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Cowboy        *CowboyResponse        `protobuf:"bytes,3,opt,name=cowboy,proto3" json:"cowboy,omitempty"` // ค่าล่าสุด (ว่างถ้า DELETED)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CowboyEvent) Reset() {
+	*x = CowboyEvent{}
+	mi := &file_proto_duelist_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CowboyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CowboyEvent) ProtoMessage() {}
+
+func (x *CowboyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CowboyEvent.ProtoReflect.Descriptor instead.
+func (*CowboyEvent) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CowboyEvent) GetType() CowboyEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return CowboyEvent_TYPE_UNSPECIFIED
+}
+
+func (x *CowboyEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CowboyEvent) GetCowboy() *CowboyResponse {
+	if x != nil {
+		return x.Cowboy
+	}
+	return nil
+}
+
+type ListCowboyVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 = ใช้ค่า default
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // ได้มาจาก next_page_token ของหน้าก่อน
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCowboyVersionsRequest) Reset() {
+	*x = ListCowboyVersionsRequest{}
+	mi := &file_proto_duelist_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCowboyVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCowboyVersionsRequest) ProtoMessage() {}
+
+func (x *ListCowboyVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCowboyVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCowboyVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCowboyVersionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListCowboyVersionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCowboyVersionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type CowboyVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Change        CowboyEvent_Type       `protobuf:"varint,1,opt,name=change,proto3,enum=duelist.CowboyEvent_Type" json:"change,omitempty"`
+	Cowboy        *CowboyResponse        `protobuf:"bytes,2,opt,name=cowboy,proto3" json:"cowboy,omitempty"`     // ค่าหลังเปลี่ยน (cowboy.version = version ของบันทึกนี้)
+	Previous      *CowboyResponse        `protobuf:"bytes,3,opt,name=previous,proto3" json:"previous,omitempty"` // ค่าก่อนเปลี่ยน (ไม่มี = ตอนสร้าง)
+	ChangedBy     string                 `protobuf:"bytes,4,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CowboyVersion) Reset() {
+	*x = CowboyVersion{}
+	mi := &file_proto_duelist_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CowboyVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CowboyVersion) ProtoMessage() {}
+
+func (x *CowboyVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CowboyVersion.ProtoReflect.Descriptor instead.
+func (*CowboyVersion) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CowboyVersion) GetChange() CowboyEvent_Type {
+	if x != nil {
+		return x.Change
+	}
+	return CowboyEvent_TYPE_UNSPECIFIED
+}
+
+func (x *CowboyVersion) GetCowboy() *CowboyResponse {
+	if x != nil {
+		return x.Cowboy
+	}
+	return nil
+}
+
+func (x *CowboyVersion) GetPrevious() *CowboyResponse {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *CowboyVersion) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *CowboyVersion) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type ListCowboyVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*CowboyVersion       `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // ว่าง = หน้าสุดท้าย
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCowboyVersionsResponse) Reset() {
+	*x = ListCowboyVersionsResponse{}
+	mi := &file_proto_duelist_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCowboyVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCowboyVersionsResponse) ProtoMessage() {}
+
+func (x *ListCowboyVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_duelist_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCowboyVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCowboyVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_duelist_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListCowboyVersionsResponse) GetVersions() []*CowboyVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListCowboyVersionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_proto_duelist_proto protoreflect.FileDescriptor
 
 const file_proto_duelist_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/duelist.proto\x12\aduelist\"\x96\x01\n" +
+	"\x13proto/duelist.proto\x12\aduelist\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb0\x01\n" +
 	"\x0eCowboyResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
 	"\x06health\x18\x03 \x01(\x05R\x06health\x12\x16\n" +
 	"\x06damage\x18\x04 \x01(\x05R\x06damage\x12\x14\n" +
 	"\x05speed\x18\x05 \x01(\x05R\x05speed\x12\x1a\n" +
-	"\baccuracy\x18\x06 \x01(\x01R\baccuracy\"\x9b\x01\n" +
+	"\baccuracy\x18\x06 \x01(\x01R\baccuracy\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\"\x9b\x01\n" +
 	"\x13CreateCowboyRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
 	"\x06health\x18\x03 \x01(\x05R\x06health\x12\x16\n" +
 	"\x06damage\x18\x04 \x01(\x05R\x06damage\x12\x14\n" +
 	"\x05speed\x18\x05 \x01(\x05R\x05speed\x12\x1a\n" +
-	"\baccuracy\x18\x06 \x01(\x01R\baccuracy\"\"\n" +
+	"\baccuracy\x18\x06 \x01(\x01R\baccuracy\"w\n" +
 	"\x10GetCowboyRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\x98\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\aversion\x18\x02 \x01(\x05H\x00R\aversion\x121\n" +
+	"\x05as_of\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x04asOfB\x04\n" +
+	"\x02at\"*\n" +
+	"\x16BatchGetCowboysRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"m\n" +
+	"\x17BatchGetCowboysResponse\x121\n" +
+	"\acowboys\x18\x01 \x03(\v2\x17.duelist.CowboyResponseR\acowboys\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"\xd8\x01\n" +
+	"\x13UpdateCowboyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06health\x18\x03 \x01(\x05R\x06health\x12\x16\n" +
+	"\x06damage\x18\x04 \x01(\x05R\x06damage\x12\x14\n" +
+	"\x05speed\x18\x05 \x01(\x05R\x05speed\x12\x1a\n" +
+	"\baccuracy\x18\x06 \x01(\x01R\baccuracy\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"%\n" +
+	"\x13DeleteCowboyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x16\n" +
+	"\x14DeleteCowboyResponse\"q\n" +
+	"\x12ListCowboysRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1f\n" +
+	"\vname_filter\x18\x03 \x01(\tR\n" +
+	"nameFilter\"p\n" +
+	"\x13ListCowboysResponse\x121\n" +
+	"\acowboys\x18\x01 \x03(\v2\x17.duelist.CowboyResponseR\acowboys\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x15\n" +
+	"\x13WatchCowboysRequest\"\xc2\x01\n" +
+	"\vCowboyEvent\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.duelist.CowboyEvent.TypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12/\n" +
+	"\x06cowboy\x18\x03 \x01(\v2\x17.duelist.CowboyResponseR\x06cowboy\"C\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\v\n" +
+	"\aUPDATED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x03\"g\n" +
+	"\x19ListCowboyVersionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x82\x02\n" +
+	"\rCowboyVersion\x121\n" +
+	"\x06change\x18\x01 \x01(\x0e2\x19.duelist.CowboyEvent.TypeR\x06change\x12/\n" +
+	"\x06cowboy\x18\x02 \x01(\v2\x17.duelist.CowboyResponseR\x06cowboy\x123\n" +
+	"\bprevious\x18\x03 \x01(\v2\x17.duelist.CowboyResponseR\bprevious\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x04 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"x\n" +
+	"\x1aListCowboyVersionsResponse\x122\n" +
+	"\bversions\x18\x01 \x03(\v2\x16.duelist.CowboyVersionR\bversions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xf1\x04\n" +
 	"\x0eDuelistService\x12E\n" +
 	"\fCreateCowboy\x12\x1c.duelist.CreateCowboyRequest\x1a\x17.duelist.CowboyResponse\x12?\n" +
-	"\tGetCowboy\x12\x19.duelist.GetCowboyRequest\x1a\x17.duelist.CowboyResponseB,Z*github.com/yourusername/cowboy_arena/protob\x06proto3"
+	"\tGetCowboy\x12\x19.duelist.GetCowboyRequest\x1a\x17.duelist.CowboyResponse\x12T\n" +
+	"\x0fBatchGetCowboys\x12\x1f.duelist.BatchGetCowboysRequest\x1a .duelist.BatchGetCowboysResponse\x12E\n" +
+	"\fUpdateCowboy\x12\x1c.duelist.UpdateCowboyRequest\x1a\x17.duelist.CowboyResponse\x12K\n" +
+	"\fDeleteCowboy\x12\x1c.duelist.DeleteCowboyRequest\x1a\x1d.duelist.DeleteCowboyResponse\x12H\n" +
+	"\vListCowboys\x12\x1b.duelist.ListCowboysRequest\x1a\x1c.duelist.ListCowboysResponse\x12D\n" +
+	"\fWatchCowboys\x12\x1c.duelist.WatchCowboysRequest\x1a\x14.duelist.CowboyEvent0\x01\x12]\n" +
+	"\x12ListCowboyVersions\x12\".duelist.ListCowboyVersionsRequest\x1a#.duelist.ListCowboyVersionsResponseB,Z*github.com/yourusername/cowboy_arena/protob\x06proto3"
 
 var (
 	file_proto_duelist_proto_rawDescOnce sync.Once
@@ -270,22 +1108,61 @@ func file_proto_duelist_proto_rawDescGZIP() []byte {
 	return file_proto_duelist_proto_rawDescData
 }
 
-var file_proto_duelist_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_duelist_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_duelist_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_duelist_proto_goTypes = []any{
-	(*CowboyResponse)(nil),      // 0: duelist.CowboyResponse
-	(*CreateCowboyRequest)(nil), // 1: duelist.CreateCowboyRequest
-	(*GetCowboyRequest)(nil),    // 2: duelist.GetCowboyRequest
+	(CowboyEvent_Type)(0),              // 0: duelist.CowboyEvent.Type
+	(*CowboyResponse)(nil),             // 1: duelist.CowboyResponse
+	(*CreateCowboyRequest)(nil),        // 2: duelist.CreateCowboyRequest
+	(*GetCowboyRequest)(nil),           // 3: duelist.GetCowboyRequest
+	(*BatchGetCowboysRequest)(nil),     // 4: duelist.BatchGetCowboysRequest
+	(*BatchGetCowboysResponse)(nil),    // 5: duelist.BatchGetCowboysResponse
+	(*UpdateCowboyRequest)(nil),        // 6: duelist.UpdateCowboyRequest
+	(*DeleteCowboyRequest)(nil),        // 7: duelist.DeleteCowboyRequest
+	(*DeleteCowboyResponse)(nil),       // 8: duelist.DeleteCowboyResponse
+	(*ListCowboysRequest)(nil),         // 9: duelist.ListCowboysRequest
+	(*ListCowboysResponse)(nil),        // 10: duelist.ListCowboysResponse
+	(*WatchCowboysRequest)(nil),        // 11: duelist.WatchCowboysRequest
+	(*CowboyEvent)(nil),                // 12: duelist.CowboyEvent
+	(*ListCowboyVersionsRequest)(nil),  // 13: duelist.ListCowboyVersionsRequest
+	(*CowboyVersion)(nil),              // 14: duelist.CowboyVersion
+	(*ListCowboyVersionsResponse)(nil), // 15: duelist.ListCowboyVersionsResponse
+	(*timestamppb.Timestamp)(nil),      // 16: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 17: google.protobuf.FieldMask
 }
 var file_proto_duelist_proto_depIdxs = []int32{
-	1, // 0: duelist.DuelistService.CreateCowboy:input_type -> duelist.CreateCowboyRequest
-	2, // 1: duelist.DuelistService.GetCowboy:input_type -> duelist.GetCowboyRequest
-	0, // 2: duelist.DuelistService.CreateCowboy:output_type -> duelist.CowboyResponse
-	0, // 3: duelist.DuelistService.GetCowboy:output_type -> duelist.CowboyResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	16, // 0: duelist.GetCowboyRequest.as_of:type_name -> google.protobuf.Timestamp
+	1,  // 1: duelist.BatchGetCowboysResponse.cowboys:type_name -> duelist.CowboyResponse
+	17, // 2: duelist.UpdateCowboyRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 3: duelist.ListCowboysResponse.cowboys:type_name -> duelist.CowboyResponse
+	0,  // 4: duelist.CowboyEvent.type:type_name -> duelist.CowboyEvent.Type
+	1,  // 5: duelist.CowboyEvent.cowboy:type_name -> duelist.CowboyResponse
+	0,  // 6: duelist.CowboyVersion.change:type_name -> duelist.CowboyEvent.Type
+	1,  // 7: duelist.CowboyVersion.cowboy:type_name -> duelist.CowboyResponse
+	1,  // 8: duelist.CowboyVersion.previous:type_name -> duelist.CowboyResponse
+	16, // 9: duelist.CowboyVersion.changed_at:type_name -> google.protobuf.Timestamp
+	14, // 10: duelist.ListCowboyVersionsResponse.versions:type_name -> duelist.CowboyVersion
+	2,  // 11: duelist.DuelistService.CreateCowboy:input_type -> duelist.CreateCowboyRequest
+	3,  // 12: duelist.DuelistService.GetCowboy:input_type -> duelist.GetCowboyRequest
+	4,  // 13: duelist.DuelistService.BatchGetCowboys:input_type -> duelist.BatchGetCowboysRequest
+	6,  // 14: duelist.DuelistService.UpdateCowboy:input_type -> duelist.UpdateCowboyRequest
+	7,  // 15: duelist.DuelistService.DeleteCowboy:input_type -> duelist.DeleteCowboyRequest
+	9,  // 16: duelist.DuelistService.ListCowboys:input_type -> duelist.ListCowboysRequest
+	11, // 17: duelist.DuelistService.WatchCowboys:input_type -> duelist.WatchCowboysRequest
+	13, // 18: duelist.DuelistService.ListCowboyVersions:input_type -> duelist.ListCowboyVersionsRequest
+	1,  // 19: duelist.DuelistService.CreateCowboy:output_type -> duelist.CowboyResponse
+	1,  // 20: duelist.DuelistService.GetCowboy:output_type -> duelist.CowboyResponse
+	5,  // 21: duelist.DuelistService.BatchGetCowboys:output_type -> duelist.BatchGetCowboysResponse
+	1,  // 22: duelist.DuelistService.UpdateCowboy:output_type -> duelist.CowboyResponse
+	8,  // 23: duelist.DuelistService.DeleteCowboy:output_type -> duelist.DeleteCowboyResponse
+	10, // 24: duelist.DuelistService.ListCowboys:output_type -> duelist.ListCowboysResponse
+	12, // 25: duelist.DuelistService.WatchCowboys:output_type -> duelist.CowboyEvent
+	15, // 26: duelist.DuelistService.ListCowboyVersions:output_type -> duelist.ListCowboyVersionsResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_duelist_proto_init() }
@@ -293,18 +1170,23 @@ func file_proto_duelist_proto_init() {
 	if File_proto_duelist_proto != nil {
 		return
 	}
+	file_proto_duelist_proto_msgTypes[2].OneofWrappers = []any{
+		(*GetCowboyRequest_Version)(nil),
+		(*GetCowboyRequest_AsOf)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_duelist_proto_rawDesc), len(file_proto_duelist_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_duelist_proto_goTypes,
 		DependencyIndexes: file_proto_duelist_proto_depIdxs,
+		EnumInfos:         file_proto_duelist_proto_enumTypes,
 		MessageInfos:      file_proto_duelist_proto_msgTypes,
 	}.Build()
 	File_proto_duelist_proto = out.File
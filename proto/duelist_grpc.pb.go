@@ -19,8 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DuelistService_CreateCowboy_FullMethodName = "/duelist.DuelistService/CreateCowboy"
-	DuelistService_GetCowboy_FullMethodName    = "/duelist.DuelistService/GetCowboy"
+	DuelistService_CreateCowboy_FullMethodName       = "/duelist.DuelistService/CreateCowboy"
+	DuelistService_GetCowboy_FullMethodName          = "/duelist.DuelistService/GetCowboy"
+	DuelistService_BatchGetCowboys_FullMethodName    = "/duelist.DuelistService/BatchGetCowboys"
+	DuelistService_UpdateCowboy_FullMethodName       = "/duelist.DuelistService/UpdateCowboy"
+	DuelistService_DeleteCowboy_FullMethodName       = "/duelist.DuelistService/DeleteCowboy"
+	DuelistService_ListCowboys_FullMethodName        = "/duelist.DuelistService/ListCowboys"
+	DuelistService_WatchCowboys_FullMethodName       = "/duelist.DuelistService/WatchCowboys"
+	DuelistService_ListCowboyVersions_FullMethodName = "/duelist.DuelistService/ListCowboyVersions"
 )
 
 // DuelistServiceClient is the client API for DuelistService service.
@@ -29,8 +35,22 @@ const (
 type DuelistServiceClient interface {
 	// สร้าง Cowboy ใหม่
 	CreateCowboy(ctx context.Context, in *CreateCowboyRequest, opts ...grpc.CallOption) (*CowboyResponse, error)
-	// ดึงข้อมูล Cowboy ตาม ID
+	// ดึงข้อมูล Cowboy ตาม ID (ระบุ version หรือ as_of เพื่อดูค่าย้อนหลังได้)
 	GetCowboy(ctx context.Context, in *GetCowboyRequest, opts ...grpc.CallOption) (*CowboyResponse, error)
+	// ดึง Cowboy หลายตัวในครั้งเดียว (ID ที่ไม่เจอจะอยู่ใน missing_ids ไม่ถือเป็น error)
+	BatchGetCowboys(ctx context.Context, in *BatchGetCowboysRequest, opts ...grpc.CallOption) (*BatchGetCowboysResponse, error)
+	// แก้ไข Cowboy เฉพาะ field ที่ระบุใน update_mask (ไม่ส่ง mask = แก้ทุก field)
+	UpdateCowboy(ctx context.Context, in *UpdateCowboyRequest, opts ...grpc.CallOption) (*CowboyResponse, error)
+	// ลบ Cowboy แบบ soft delete (ประวัติการดวลยังอ้างถึงได้)
+	DeleteCowboy(ctx context.Context, in *DeleteCowboyRequest, opts ...grpc.CallOption) (*DeleteCowboyResponse, error)
+	// ดึงรายชื่อ Cowboy ทีละหน้า
+	ListCowboys(ctx context.Context, in *ListCowboysRequest, opts ...grpc.CallOption) (*ListCowboysResponse, error)
+	// ติดตามการเปลี่ยนแปลงของ Cowboy แบบ real-time (ใช้ invalidate cache ฝั่ง Arena)
+	// ถ้า subscriber ตามไม่ทัน server จะตัด stream ด้วย UNAVAILABLE ให้ต่อใหม่
+	WatchCowboys(ctx context.Context, in *WatchCowboysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CowboyEvent], error)
+	// ประวัติการเปลี่ยนแปลงของ Cowboy 1 ตัว เรียงจาก version เก่าไปใหม่
+	// ผู้สั่งเปลี่ยนอ่านจาก metadata "x-actor" ของ request ที่แก้ค่า
+	ListCowboyVersions(ctx context.Context, in *ListCowboyVersionsRequest, opts ...grpc.CallOption) (*ListCowboyVersionsResponse, error)
 }
 
 type duelistServiceClient struct {
@@ -61,14 +81,97 @@ func (c *duelistServiceClient) GetCowboy(ctx context.Context, in *GetCowboyReque
 	return out, nil
 }
 
+func (c *duelistServiceClient) BatchGetCowboys(ctx context.Context, in *BatchGetCowboysRequest, opts ...grpc.CallOption) (*BatchGetCowboysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetCowboysResponse)
+	err := c.cc.Invoke(ctx, DuelistService_BatchGetCowboys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *duelistServiceClient) UpdateCowboy(ctx context.Context, in *UpdateCowboyRequest, opts ...grpc.CallOption) (*CowboyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CowboyResponse)
+	err := c.cc.Invoke(ctx, DuelistService_UpdateCowboy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *duelistServiceClient) DeleteCowboy(ctx context.Context, in *DeleteCowboyRequest, opts ...grpc.CallOption) (*DeleteCowboyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCowboyResponse)
+	err := c.cc.Invoke(ctx, DuelistService_DeleteCowboy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *duelistServiceClient) ListCowboys(ctx context.Context, in *ListCowboysRequest, opts ...grpc.CallOption) (*ListCowboysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCowboysResponse)
+	err := c.cc.Invoke(ctx, DuelistService_ListCowboys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *duelistServiceClient) WatchCowboys(ctx context.Context, in *WatchCowboysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CowboyEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DuelistService_ServiceDesc.Streams[0], DuelistService_WatchCowboys_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCowboysRequest, CowboyEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DuelistService_WatchCowboysClient = grpc.ServerStreamingClient[CowboyEvent]
+
+func (c *duelistServiceClient) ListCowboyVersions(ctx context.Context, in *ListCowboyVersionsRequest, opts ...grpc.CallOption) (*ListCowboyVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCowboyVersionsResponse)
+	err := c.cc.Invoke(ctx, DuelistService_ListCowboyVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DuelistServiceServer is the server API for DuelistService service.
 // All implementations must embed UnimplementedDuelistServiceServer
 // for forward compatibility.
 type DuelistServiceServer interface {
 	// สร้าง Cowboy ใหม่
 	CreateCowboy(context.Context, *CreateCowboyRequest) (*CowboyResponse, error)
-	// ดึงข้อมูล Cowboy ตาม ID
+	// ดึงข้อมูล Cowboy ตาม ID (ระบุ version หรือ as_of เพื่อดูค่าย้อนหลังได้)
 	GetCowboy(context.Context, *GetCowboyRequest) (*CowboyResponse, error)
+	// ดึง Cowboy หลายตัวในครั้งเดียว (ID ที่ไม่เจอจะอยู่ใน missing_ids ไม่ถือเป็น error)
+	BatchGetCowboys(context.Context, *BatchGetCowboysRequest) (*BatchGetCowboysResponse, error)
+	// แก้ไข Cowboy เฉพาะ field ที่ระบุใน update_mask (ไม่ส่ง mask = แก้ทุก field)
+	UpdateCowboy(context.Context, *UpdateCowboyRequest) (*CowboyResponse, error)
+	// ลบ Cowboy แบบ soft delete (ประวัติการดวลยังอ้างถึงได้)
+	DeleteCowboy(context.Context, *DeleteCowboyRequest) (*DeleteCowboyResponse, error)
+	// ดึงรายชื่อ Cowboy ทีละหน้า
+	ListCowboys(context.Context, *ListCowboysRequest) (*ListCowboysResponse, error)
+	// ติดตามการเปลี่ยนแปลงของ Cowboy แบบ real-time (ใช้ invalidate cache ฝั่ง Arena)
+	// ถ้า subscriber ตามไม่ทัน server จะตัด stream ด้วย UNAVAILABLE ให้ต่อใหม่
+	WatchCowboys(*WatchCowboysRequest, grpc.ServerStreamingServer[CowboyEvent]) error
+	// ประวัติการเปลี่ยนแปลงของ Cowboy 1 ตัว เรียงจาก version เก่าไปใหม่
+	// ผู้สั่งเปลี่ยนอ่านจาก metadata "x-actor" ของ request ที่แก้ค่า
+	ListCowboyVersions(context.Context, *ListCowboyVersionsRequest) (*ListCowboyVersionsResponse, error)
 	mustEmbedUnimplementedDuelistServiceServer()
 }
 
@@ -85,6 +188,24 @@ func (UnimplementedDuelistServiceServer) CreateCowboy(context.Context, *CreateCo
 func (UnimplementedDuelistServiceServer) GetCowboy(context.Context, *GetCowboyRequest) (*CowboyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCowboy not implemented")
 }
+func (UnimplementedDuelistServiceServer) BatchGetCowboys(context.Context, *BatchGetCowboysRequest) (*BatchGetCowboysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetCowboys not implemented")
+}
+func (UnimplementedDuelistServiceServer) UpdateCowboy(context.Context, *UpdateCowboyRequest) (*CowboyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCowboy not implemented")
+}
+func (UnimplementedDuelistServiceServer) DeleteCowboy(context.Context, *DeleteCowboyRequest) (*DeleteCowboyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCowboy not implemented")
+}
+func (UnimplementedDuelistServiceServer) ListCowboys(context.Context, *ListCowboysRequest) (*ListCowboysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCowboys not implemented")
+}
+func (UnimplementedDuelistServiceServer) WatchCowboys(*WatchCowboysRequest, grpc.ServerStreamingServer[CowboyEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchCowboys not implemented")
+}
+func (UnimplementedDuelistServiceServer) ListCowboyVersions(context.Context, *ListCowboyVersionsRequest) (*ListCowboyVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCowboyVersions not implemented")
+}
 func (UnimplementedDuelistServiceServer) mustEmbedUnimplementedDuelistServiceServer() {}
 func (UnimplementedDuelistServiceServer) testEmbeddedByValue()                        {}
 
@@ -142,6 +263,107 @@ func _DuelistService_GetCowboy_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DuelistService_BatchGetCowboys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetCowboysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DuelistServiceServer).BatchGetCowboys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DuelistService_BatchGetCowboys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DuelistServiceServer).BatchGetCowboys(ctx, req.(*BatchGetCowboysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DuelistService_UpdateCowboy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCowboyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DuelistServiceServer).UpdateCowboy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DuelistService_UpdateCowboy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DuelistServiceServer).UpdateCowboy(ctx, req.(*UpdateCowboyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DuelistService_DeleteCowboy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCowboyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DuelistServiceServer).DeleteCowboy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DuelistService_DeleteCowboy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DuelistServiceServer).DeleteCowboy(ctx, req.(*DeleteCowboyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DuelistService_ListCowboys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCowboysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DuelistServiceServer).ListCowboys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DuelistService_ListCowboys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DuelistServiceServer).ListCowboys(ctx, req.(*ListCowboysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DuelistService_WatchCowboys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCowboysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DuelistServiceServer).WatchCowboys(m, &grpc.GenericServerStream[WatchCowboysRequest, CowboyEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DuelistService_WatchCowboysServer = grpc.ServerStreamingServer[CowboyEvent]
+
+func _DuelistService_ListCowboyVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCowboyVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DuelistServiceServer).ListCowboyVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DuelistService_ListCowboyVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DuelistServiceServer).ListCowboyVersions(ctx, req.(*ListCowboyVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DuelistService_ServiceDesc is the grpc.ServiceDesc for DuelistService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,7 +379,33 @@ var DuelistService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCowboy",
 			Handler:    _DuelistService_GetCowboy_Handler,
 		},
+		{
+			MethodName: "BatchGetCowboys",
+			Handler:    _DuelistService_BatchGetCowboys_Handler,
+		},
+		{
+			MethodName: "UpdateCowboy",
+			Handler:    _DuelistService_UpdateCowboy_Handler,
+		},
+		{
+			MethodName: "DeleteCowboy",
+			Handler:    _DuelistService_DeleteCowboy_Handler,
+		},
+		{
+			MethodName: "ListCowboys",
+			Handler:    _DuelistService_ListCowboys_Handler,
+		},
+		{
+			MethodName: "ListCowboyVersions",
+			Handler:    _DuelistService_ListCowboyVersions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCowboys",
+			Handler:       _DuelistService_WatchCowboys_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/duelist.proto",
 }
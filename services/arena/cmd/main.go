@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -13,9 +19,12 @@ import (
 	"api/pkg/config" // ✅ เรียกใช้ Config Package
 	"api/pkg/database"
 	pb "api/proto"
+	"api/services/arena/internal/adapters/cache"
 	"api/services/arena/internal/adapters/client"
 	"api/services/arena/internal/adapters/handler"
 	"api/services/arena/internal/adapters/repository"
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
 	"api/services/arena/internal/core/services"
 )
 
@@ -27,11 +36,81 @@ func main() {
 	if p := os.Getenv("ARENA_PORT"); p != "" {
 		cfg.AppPort = p
 	}
+	// gRPC ใช้อีก port (ไม่ตั้ง = 50052)
+	grpcPort := os.Getenv("ARENA_GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50052"
+	}
+	// DB แยกต่อ service ได้ (ไม่ตั้ง = ใช้ DB_DSN กลาง)
+	if dsn := os.Getenv("ARENA_DB_DSN"); dsn != "" {
+		cfg.DBUrl = dsn
+	}
+
+	// ctx จบเมื่อได้ SIGINT / SIGTERM (ใช้ทั้งตอนรอ DB และตอน shutdown)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 2. Init DB (ใช้ cfg.DBUrl)
-	db, err := database.GetInstance(cfg.DBUrl)
+	// 2. Init DB (เลือกจาก scheme ของ DB_DSN, memory:// = ไม่ใช้ DB)
+	driver, _, err := database.ParseDSN(cfg.DBUrl)
 	if err != nil {
-		log.Fatalf("❌ Failed to initialize database: %v", err)
+		log.Fatalf("❌ Invalid DB_DSN: %v", err)
+	}
+	// subcommand (migrate / rollback / status) จัดการ schema แล้วจบเลย ไม่ start server
+	command := os.Args[1:]
+	if len(command) > 0 && driver == database.DriverMemory {
+		log.Fatalf("❌ %s has no schema to migrate", cfg.DBUrl)
+	}
+
+	var (
+		db             *database.DB // nil = in-memory
+		repoAdapter    ports.BattleRepository
+		ratingRepo     ports.RatingRepository
+		tournamentRepo ports.TournamentRepository
+	)
+	if driver == database.DriverMemory {
+		log.Println("🧠 Using in-memory repositories (data is lost on exit)")
+		store := repository.NewMemoryStore(float64(cfg.EloK))
+		repoAdapter = repository.NewMemoryRepository(store)
+		ratingRepo = repository.NewMemoryRatingRepository(store)
+		tournamentRepo = repository.NewMemoryTournamentRepository(store)
+	} else {
+		db, err = database.Open(ctx, database.Options{
+			DSN:             cfg.DBUrl,
+			ReplicaDSN:      cfg.DBReplicaUrl,
+			MaxOpenConns:    cfg.DBMaxOpen,
+			MaxIdleConns:    cfg.DBMaxIdle,
+			ConnMaxLifetime: time.Duration(cfg.DBMaxLifetime) * time.Second,
+			ConnectRetries:  cfg.DBRetries,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize database: %v", err)
+		}
+		migrator, err := database.NewMigrator(db.Primary(), "arena", repository.Migrations())
+		if err != nil {
+			log.Fatalf("❌ Invalid migrations: %v", err)
+		}
+		if len(command) > 0 {
+			err := database.RunMigrationCommand(ctx, migrator, command, os.Stdout)
+			db.Close()
+			if err != nil {
+				log.Fatalf("❌ %v", err)
+			}
+			return
+		}
+		if cfg.AutoMigrate {
+			if _, err := migrator.Up(ctx); err != nil {
+				log.Fatalf("❌ Failed to migrate database: %v", err)
+			}
+		}
+		// ไม่ยอม start ถ้า schema ไม่ตรงกับโค้ด
+		if err := migrator.EnsureCurrent(ctx); err != nil {
+			log.Fatalf("❌ %v (run `arena migrate` first)", err)
+		}
+		// gorm repository ใช้ได้ทั้ง MySQL และ SQLite (ประวัติการดวลอ่านจาก replica ถ้ามี)
+		// battle ทุกตัวอัปเดตคะแนน Elo ใน transaction เดียวกับที่บันทึก
+		repoAdapter = repository.NewMySQLRepository(db.Primary(), db.Reader(), float64(cfg.EloK))
+		ratingRepo = repository.NewRatingRepository(db.Primary())
+		tournamentRepo = repository.NewTournamentRepository(db.Primary(), float64(cfg.EloK))
 	}
 
 	// 3. Init gRPC Client (ใช้ cfg.DuelistTarget)
@@ -43,17 +122,98 @@ func main() {
 	grpcClient := pb.NewDuelistServiceClient(conn)
 
 	// 4. Setup Layers (เหมือนเดิม)
-	repoAdapter := repository.NewMySQLRepository(db)
-	clientAdapter := client.NewGrpcClientAdapter(grpcClient)
-	svc := services.NewArenaService(clientAdapter, repoAdapter)
+	// ครอบ gRPC ด้วย cache แล้วให้ Duelist แจ้งเมื่อมีการเปลี่ยนแปลง
+	cowboyCache := cache.NewCachingProvider(
+		client.NewGrpcClientAdapter(grpcClient),
+		cache.NewLRU(cfg.CacheSize, time.Duration(cfg.CacheTTL)*time.Second),
+	)
+	go client.WatchCowboys(ctx, grpcClient, cowboyCache)
+	rules := domain.Rules{MaxTurns: cfg.MaxTurns}
+
+	ratingSvc := services.NewRatingService(ratingRepo)
+	ratingHandler := handler.NewRatingHandler(ratingSvc)
+
+	svc := services.NewArenaService(cowboyCache, repoAdapter, rules)
 	httpHandler := handler.NewHttpHandler(svc)
 
+	tournamentSvc := services.NewTournamentService(cowboyCache, tournamentRepo, rules)
+	tournamentHandler := handler.NewTournamentHandler(tournamentSvc)
+
+	// เอกสาร OpenAPI ใช้ตรวจทุก request ก่อนถึง handler
+	spec, err := handler.NewOpenAPI()
+	if err != nil {
+		log.Fatalf("❌ Invalid OpenAPI document: %v", err)
+	}
+
 	// 5. Register Routes & Start
+	http.HandleFunc("/openapi.json", spec.HandleSpec)
 	http.HandleFunc("/duel", httpHandler.HandleDuel)
+	http.HandleFunc("/duel/stream", httpHandler.HandleDuelStream)
+	http.HandleFunc("/duel/ws", httpHandler.HandleDuelWebSocket)
 	http.HandleFunc("/history", httpHandler.HandleHistory)
+	http.HandleFunc("/battles/{id}", httpHandler.HandleBattle)
+	http.HandleFunc("/cowboys/{id}/stats", httpHandler.HandleFighterStats)
+	http.HandleFunc("/matchup", httpHandler.HandleMatchup)
+	http.HandleFunc("/series", httpHandler.HandleSeries)
+	http.HandleFunc("/series/{id}", httpHandler.HandleGetSeries)
+	http.HandleFunc("/tournaments", tournamentHandler.HandleCreate)
+	http.HandleFunc("/tournaments/{id}", tournamentHandler.HandleGet)
+	http.HandleFunc("/tournaments/{id}/advance", tournamentHandler.HandleAdvance)
+	http.HandleFunc("/tournaments/{id}/rounds/{round}/replay", tournamentHandler.HandleReplayRound)
+	http.HandleFunc("/leaderboard", ratingHandler.HandleLeaderboard)
+	http.HandleFunc("/cowboys/{id}/rating", ratingHandler.HandleCowboyRating)
+
+	// 6. Start gRPC (ใช้ ArenaService ตัวเดียวกับ HTTP)
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("❌ Failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterArenaServiceServer(grpcServer, handler.NewGrpcHandler(svc))
+	go func() {
+		fmt.Printf("⚔️  Arena gRPC running on port :%s\n", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("❌ Failed to serve: %v", err)
+		}
+	}()
+
+	httpServer := &http.Server{Addr: ":" + cfg.AppPort, Handler: spec.Validate(http.DefaultServeMux)}
+	go func() {
+		fmt.Printf("⚔️  Arena Service running on port :%s\n", cfg.AppPort)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("❌ Server failed to start: %v", err)
+		}
+	}()
+
+	// 7. รอสัญญาณปิด แล้วปิด server ก่อนค่อยปิด DB (request ที่ค้างอยู่จะได้จบก่อน)
+	<-ctx.Done()
+	log.Println("🛑 Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️  HTTP shutdown: %v", err)
+	}
+	stopGRPC(shutdownCtx, grpcServer)
+	if db != nil {
+		if err := db.Close(); err != nil {
+			log.Printf("⚠️  Database close: %v", err)
+		}
+	}
+}
+
+// shutdownTimeout : เวลาที่ให้ request ที่ค้างอยู่ (รวม SSE / ดวลสด) ทำต่อจนจบตอนปิด service
+const shutdownTimeout = 10 * time.Second
 
-	fmt.Printf("⚔️  Arena Service running on port :%s\n", cfg.AppPort)
-	if err := http.ListenAndServe(":"+cfg.AppPort, nil); err != nil {
-		log.Fatalf("❌ Server failed to start: %v", err)
+// stopGRPC : รอ RPC ที่ค้างอยู่ให้จบ ถ้าหมดเวลาก็ตัดทิ้ง
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
 	}
 }
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"api/services/arena/internal/core/domain/entity"
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSize = 1000
+	DefaultTTL  = time.Minute
+)
+
+// Backend : ที่เก็บ cache (สลับเป็น Redis หรืออื่นๆ ได้ ขอแค่ทำตาม interface นี้)
+type Backend interface {
+	Get(id string) (*entity.Cowboy, bool)
+	Set(c *entity.Cowboy)
+	Delete(id string)
+	// Purge : ล้างทั้งหมด (ใช้ตอนไม่แน่ใจว่าพลาด event ไปหรือเปล่า)
+	Purge()
+}
+
+type lruEntry struct {
+	cowboy    *entity.Cowboy
+	expiresAt time.Time
+}
+
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	order *list.List // หน้าสุด = ใช้ล่าสุด
+	items map[string]*list.Element
+}
+
+// NewLRU : cache ใน memory เก็บไม่เกิน size ตัว แต่ละตัวอยู่ได้ไม่เกิน ttl (0 = ค่า default)
+func NewLRU(size int, ttl time.Duration) Backend {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &lru{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+func (l *lru) Get(id string) (*entity.Cowboy, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.items[id]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if time.Now().After(e.expiresAt) {
+		l.remove(el)
+		return nil, false
+	}
+	l.order.MoveToFront(el)
+	return e.cowboy.Clone(), true
+}
+
+func (l *lru) Set(c *entity.Cowboy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := &lruEntry{cowboy: c.Clone(), expiresAt: time.Now().Add(l.ttl)}
+	if el, ok := l.items[c.ID]; ok {
+		el.Value = e
+		l.order.MoveToFront(el)
+		return
+	}
+	l.items[c.ID] = l.order.PushFront(e)
+
+	// เกินขนาด = ทิ้งตัวที่ไม่ได้ใช้นานที่สุด
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *lru) Delete(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.items[id]; ok {
+		l.remove(el)
+	}
+}
+
+func (l *lru) Purge() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.order.Init()
+	l.items = make(map[string]*list.Element, l.size)
+}
+
+func (l *lru) remove(el *list.Element) {
+	l.order.Remove(el)
+	delete(l.items, el.Value.(*lruEntry).cowboy.ID)
+}
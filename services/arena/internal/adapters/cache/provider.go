@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"api/services/arena/internal/core/domain/entity"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"sync"
+)
+
+// CachingProvider : ครอบ CowboyProvider ตัวจริง ดึงจาก cache ก่อน ไม่เจอค่อยไปถาม
+// ค่าที่คืนเป็นสำเนาเสมอ ผู้เรียกแก้ได้โดยไม่กระทบ cache
+type CachingProvider struct {
+	next    ports.CowboyProvider
+	backend Backend
+
+	// generation ของแต่ละ key: Invalidate / Purge เพิ่มค่า ถ้าระหว่างไปดึงค่ามีการเปลี่ยน = ค่าที่ได้อาจเก่าแล้ว ห้าม Set
+	// map โตตามจำนวน Cowboy ที่เคยถูก invalidate เท่านั้น
+	mu    sync.Mutex
+	gens  map[string]uint64
+	epoch uint64 // เพิ่มทุกครั้งที่ Purge
+}
+
+// generation : ค่าที่ต้องจำไว้ก่อนไปดึงจากตัวจริง แล้วส่งให้ setIfCurrent
+type generation struct {
+	epoch, key uint64
+}
+
+func NewCachingProvider(next ports.CowboyProvider, backend Backend) *CachingProvider {
+	return &CachingProvider{next: next, backend: backend, gens: make(map[string]uint64)}
+}
+
+func (p *CachingProvider) generation(id string) generation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return generation{epoch: p.epoch, key: p.gens[id]}
+}
+
+// setIfCurrent : ใส่ cache เฉพาะถ้ายังไม่มี Invalidate / Purge เกิดขึ้นหลังเริ่มดึง (ถือ lock ตอน Set กัน Invalidate แทรก)
+func (p *CachingProvider) setIfCurrent(c *entity.Cowboy, gen generation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.epoch != gen.epoch || p.gens[c.ID] != gen.key {
+		return
+	}
+	p.backend.Set(c)
+}
+
+func (p *CachingProvider) GetCowboy(ctx context.Context, id string) (*entity.Cowboy, error) {
+	if c, ok := p.backend.Get(id); ok {
+		return c, nil
+	}
+
+	gen := p.generation(id)
+	c, err := p.next.GetCowboy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p.setIfCurrent(c, gen)
+	return c, nil
+}
+
+func (p *CachingProvider) GetCowboys(ctx context.Context, ids []string) ([]*entity.Cowboy, error) {
+	cowboys := make([]*entity.Cowboy, len(ids))
+	var missing []string
+	for i, id := range ids {
+		if c, ok := p.backend.Get(id); ok {
+			cowboys[i] = c
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return cowboys, nil
+	}
+
+	// ถามเฉพาะตัวที่ไม่มีใน cache ใน round-trip เดียว
+	gens := make(map[string]generation, len(missing))
+	for _, id := range missing {
+		gens[id] = p.generation(id)
+	}
+	fetched, err := p.next.GetCowboys(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Cowboy, len(fetched))
+	for _, c := range fetched {
+		p.setIfCurrent(c, gens[c.ID])
+		byID[c.ID] = c
+	}
+	for i, id := range ids {
+		if cowboys[i] == nil {
+			cowboys[i] = byID[id].Clone()
+		}
+	}
+	return cowboys, nil
+}
+
+// Invalidate : ทิ้งค่าของ Cowboy ตัวนี้ (เรียกเมื่อได้ event ว่าถูกแก้/ลบ)
+func (p *CachingProvider) Invalidate(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.gens[id]++
+	p.backend.Delete(id)
+}
+
+// Purge : ทิ้งทั้งหมด (เรียกตอนต่อ stream ใหม่ เพราะอาจพลาด event ไประหว่างหลุด)
+func (p *CachingProvider) Purge() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.epoch++
+	p.backend.Purge()
+}
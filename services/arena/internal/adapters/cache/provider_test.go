@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"api/services/arena/internal/core/domain/entity"
+	"context"
+	"sync"
+	"testing"
+)
+
+// slowProvider : CowboyProvider ปลอมที่ค้างอยู่กลางการดึงจนกว่าจะปล่อย (จำลอง fetch ที่ช้ากว่า event)
+type slowProvider struct {
+	mu      sync.Mutex
+	health  int
+	calls   int
+	started chan struct{}
+	release chan struct{}
+}
+
+func newSlowProvider() *slowProvider {
+	return &slowProvider{health: 100, started: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+// fetch : อ่านค่าปัจจุบันไว้ก่อน แล้วรอให้ test ปล่อย (ค่าที่คืนจึงเก่ากว่าตอนคืน)
+func (p *slowProvider) fetch(id string) *entity.Cowboy {
+	p.mu.Lock()
+	p.calls++
+	c := &entity.Cowboy{ID: id, Name: id, Health: p.health, Damage: 10, Speed: 10, Accuracy: 0.5}
+	p.mu.Unlock()
+
+	p.started <- struct{}{}
+	<-p.release
+	return c
+}
+
+func (p *slowProvider) GetCowboy(ctx context.Context, id string) (*entity.Cowboy, error) {
+	return p.fetch(id), nil
+}
+
+func (p *slowProvider) GetCowboys(ctx context.Context, ids []string) ([]*entity.Cowboy, error) {
+	var out []*entity.Cowboy
+	for _, id := range ids {
+		out = append(out, p.fetch(id))
+	}
+	return out, nil
+}
+
+// update : Duelist แก้ค่าแล้ว (ตามด้วย event ที่ทำให้ invalidate)
+func (p *slowProvider) update(health int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.health = health
+}
+
+func TestInFlightFetchDoesNotRestoreStaleValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		get        func(p *CachingProvider) error
+		invalidate func(p *CachingProvider)
+	}{
+		{
+			name:       "GetCowboy / Invalidate",
+			get:        func(p *CachingProvider) error { _, err := p.GetCowboy(context.Background(), "kid"); return err },
+			invalidate: func(p *CachingProvider) { p.Invalidate("kid") },
+		},
+		{
+			name: "GetCowboys / Invalidate",
+			get: func(p *CachingProvider) error {
+				_, err := p.GetCowboys(context.Background(), []string{"kid"})
+				return err
+			},
+			invalidate: func(p *CachingProvider) { p.Invalidate("kid") },
+		},
+		{
+			name:       "GetCowboy / Purge",
+			get:        func(p *CachingProvider) error { _, err := p.GetCowboy(context.Background(), "kid"); return err },
+			invalidate: func(p *CachingProvider) { p.Purge() },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newSlowProvider()
+			p := NewCachingProvider(next, NewLRU(10, 0))
+
+			// 1. เริ่มดึงค่าเก่า (health 100) แล้วค้างไว้
+			done := make(chan error)
+			go func() { done <- tt.get(p) }()
+			<-next.started
+
+			// 2. ระหว่างนั้นค่าถูกแก้ และ event invalidate มาถึงก่อน fetch จะเสร็จ
+			next.update(50)
+			tt.invalidate(p)
+
+			// 3. fetch เก่าเสร็จทีหลัง ต้องไม่เอาค่าเก่าไปใส่ cache
+			next.release <- struct{}{}
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+			if c, ok := p.backend.Get("kid"); ok {
+				t.Fatalf("cache holds health %d from a fetch that started before the invalidation", c.Health)
+			}
+
+			// ครั้งถัดไปต้องไปดึงค่าใหม่ แล้ว cache ได้ตามปกติ
+			close(next.release)
+			c, err := p.GetCowboy(context.Background(), "kid")
+			if err != nil || c.Health != 50 {
+				t.Fatalf("GetCowboy after invalidation = %+v, %v, want health 50", c, err)
+			}
+			if _, ok := p.backend.Get("kid"); !ok {
+				t.Fatal("fresh value was not cached")
+			}
+			if next.calls != 2 {
+				t.Fatalf("provider called %d times, want 2", next.calls)
+			}
+		})
+	}
+}
+
+func TestUnrelatedInvalidationKeepsFetch(t *testing.T) {
+	next := newSlowProvider()
+	p := NewCachingProvider(next, NewLRU(10, 0))
+
+	done := make(chan error)
+	go func() {
+		_, err := p.GetCowboy(context.Background(), "kid")
+		done <- err
+	}()
+	<-next.started
+	p.Invalidate("doc")
+	close(next.release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := p.backend.Get("kid"); !ok {
+		t.Fatal("invalidating another cowboy dropped this fetch")
+	}
+}
@@ -1,11 +1,17 @@
 package client
 
 import (
-	"context"
 	pb "api/proto"
-	"api/services/arena/internal/core/ports"
+	"api/services/arena/internal/core/domain"
 	"api/services/arena/internal/core/domain/entity"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"fmt"
+	"strings"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type grpcClientAdapter struct {
@@ -16,15 +22,49 @@ func NewGrpcClientAdapter(client pb.DuelistServiceClient) ports.CowboyProvider {
 	return &grpcClientAdapter{client: client}
 }
 
-func (g *grpcClientAdapter) GetCowboy(id string) (*entity.Cowboy, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (g *grpcClientAdapter) GetCowboy(ctx context.Context, id string) (*entity.Cowboy, error) {
+	// ต่อ timeout จาก ctx ของ request (ถ้า client ยกเลิก ก็ยกเลิก gRPC ไปด้วย)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	resp, err := g.client.GetCowboy(ctx, &pb.GetCowboyRequest{Id: id})
 	if err != nil {
-		return nil, err
+		return nil, fromStatus(err)
+	}
+
+	return toEntity(resp), nil
+}
+
+func (g *grpcClientAdapter) GetCowboys(ctx context.Context, ids []string) ([]*entity.Cowboy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := g.client.BatchGetCowboys(ctx, &pb.BatchGetCowboysRequest{Ids: ids})
+	if err != nil {
+		return nil, fromStatus(err)
 	}
+	if len(resp.MissingIds) > 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCowboyNotFound, strings.Join(resp.MissingIds, ", "))
+	}
+
+	byID := make(map[string]*pb.CowboyResponse, len(resp.Cowboys))
+	for _, c := range resp.Cowboys {
+		byID[c.Id] = c
+	}
+
+	// Duelist รวม ID ซ้ำให้แล้ว ฝั่งนี้แตกกลับตามลำดับที่ขอ (แต่ละช่องเป็นสำเนาของตัวเอง)
+	cowboys := make([]*entity.Cowboy, 0, len(ids))
+	for _, id := range ids {
+		c, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrCowboyNotFound, id)
+		}
+		cowboys = append(cowboys, toEntity(c))
+	}
+	return cowboys, nil
+}
 
+func toEntity(resp *pb.CowboyResponse) *entity.Cowboy {
 	return &entity.Cowboy{
 		ID:       resp.Id,
 		Name:     resp.Name,
@@ -32,5 +72,23 @@ func (g *grpcClientAdapter) GetCowboy(id string) (*entity.Cowboy, error) {
 		Damage:   int(resp.Damage),
 		Speed:    int(resp.Speed),
 		Accuracy: resp.Accuracy,
-	}, nil
-}
\ No newline at end of file
+		Version:  int(resp.Version),
+	}
+}
+
+// fromStatus : แปลง gRPC status จาก Duelist กลับเป็น Domain Error ของ Arena
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", domain.ErrCowboyNotFound, st.Message())
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", domain.ErrInvalidArgument, st.Message())
+	case codes.AlreadyExists:
+		return fmt.Errorf("%w: %s", domain.ErrConflict, st.Message())
+	}
+	return err
+}
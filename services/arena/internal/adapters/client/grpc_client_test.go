@@ -0,0 +1,84 @@
+package client
+
+import (
+	pb "api/proto"
+	"api/services/arena/internal/core/domain"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeDuelist : DuelistServiceClient ปลอม ตอบ BatchGetCowboys ตามที่ตั้งไว้
+type fakeDuelist struct {
+	pb.DuelistServiceClient
+	resp *pb.BatchGetCowboysResponse
+	err  error
+	got  []string
+}
+
+func (f *fakeDuelist) BatchGetCowboys(ctx context.Context, req *pb.BatchGetCowboysRequest, opts ...grpc.CallOption) (*pb.BatchGetCowboysResponse, error) {
+	f.got = req.Ids
+	return f.resp, f.err
+}
+
+func TestGetCowboys(t *testing.T) {
+	kid := &pb.CowboyResponse{Id: "kid", Name: "Kid", Health: 100, Damage: 20, Speed: 10, Accuracy: 0.5, Version: 3}
+	doc := &pb.CowboyResponse{Id: "doc", Name: "Doc", Health: 90, Damage: 25, Speed: 12, Accuracy: 0.6, Version: 1}
+	unavailable := status.Error(codes.Unavailable, "duelist is down")
+
+	tests := []struct {
+		name    string
+		ids     []string
+		resp    *pb.BatchGetCowboysResponse
+		err     error
+		wantIDs []string
+		wantErr error // nil = ต้องสำเร็จ
+		rawErr  bool  // error ที่ไม่รู้จักต้องส่งต่อตามเดิม
+	}{
+		{"order follows the request", []string{"doc", "kid"}, &pb.BatchGetCowboysResponse{Cowboys: []*pb.CowboyResponse{kid, doc}}, nil, []string{"doc", "kid"}, nil, false},
+		{"duplicate ids get their own copy", []string{"kid", "kid"}, &pb.BatchGetCowboysResponse{Cowboys: []*pb.CowboyResponse{kid}}, nil, []string{"kid", "kid"}, nil, false},
+		{"any missing id = not found", []string{"kid", "ghost"}, &pb.BatchGetCowboysResponse{Cowboys: []*pb.CowboyResponse{kid}, MissingIds: []string{"ghost"}}, nil, nil, domain.ErrCowboyNotFound, false},
+		{"id absent without being reported", []string{"kid", "doc"}, &pb.BatchGetCowboysResponse{Cowboys: []*pb.CowboyResponse{kid}}, nil, nil, domain.ErrCowboyNotFound, false},
+		{"NotFound", []string{"kid"}, nil, status.Error(codes.NotFound, "no"), nil, domain.ErrCowboyNotFound, false},
+		{"InvalidArgument", []string{"kid"}, nil, status.Error(codes.InvalidArgument, "too many ids"), nil, domain.ErrInvalidArgument, false},
+		{"AlreadyExists", []string{"kid"}, nil, status.Error(codes.AlreadyExists, "dup"), nil, domain.ErrConflict, false},
+		{"unknown code passes through", []string{"kid"}, nil, unavailable, nil, unavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDuelist{resp: tt.resp, err: tt.err}
+			cowboys, err := NewGrpcClientAdapter(fake).GetCowboys(context.Background(), tt.ids)
+			if !slices.Equal(fake.got, tt.ids) {
+				t.Fatalf("sent ids %v, want %v in one call", fake.got, tt.ids)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCowboys() = %v, want %v", err, tt.wantErr)
+			}
+			if tt.rawErr && status.Code(err) != status.Code(tt.err) {
+				t.Fatalf("GetCowboys() lost the gRPC status: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			var ids []string
+			for _, c := range cowboys {
+				ids = append(ids, c.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Fatalf("GetCowboys() = %v, want %v", ids, tt.wantIDs)
+			}
+			if len(cowboys) == 2 && cowboys[0] == cowboys[1] {
+				t.Fatal("duplicate ids share one *entity.Cowboy")
+			}
+			if c := cowboys[slices.Index(ids, "kid")]; c.Health != 100 || c.Accuracy != 0.5 || c.Version != 3 {
+				t.Fatalf("kid mapped to %+v", *c)
+			}
+		})
+	}
+}
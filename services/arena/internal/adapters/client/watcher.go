@@ -0,0 +1,61 @@
+package client
+
+import (
+	pb "api/proto"
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	minWatchBackoff = 500 * time.Millisecond
+	maxWatchBackoff = 30 * time.Second
+)
+
+// Invalidator : สิ่งที่ต้องรู้เมื่อ Cowboy เปลี่ยน (เช่น cache.CachingProvider)
+type Invalidator interface {
+	Invalidate(id string)
+	Purge()
+}
+
+// WatchCowboys : ฟัง event จาก Duelist แล้ว invalidate ไปเรื่อยๆ จนกว่า ctx จะจบ
+// stream หลุดเมื่อไหร่จะล้างทั้งหมดแล้วต่อใหม่ (ระหว่างหลุดอาจพลาด event ไป)
+func WatchCowboys(ctx context.Context, client pb.DuelistServiceClient, inv Invalidator) {
+	backoff := minWatchBackoff
+	for {
+		err := watchOnce(ctx, client, inv, func() { backoff = minWatchBackoff })
+		if ctx.Err() != nil {
+			return
+		}
+		inv.Purge()
+		log.Printf("⚠️  Cowboy watch stream lost: %v (retrying in %s)", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxWatchBackoff)
+	}
+}
+
+// watchOnce : ต่อ stream 1 ครั้ง แล้วรับ event จนกว่าจะหลุด
+func watchOnce(ctx context.Context, client pb.DuelistServiceClient, inv Invalidator, connected func()) error {
+	stream, err := client.WatchCowboys(ctx, &pb.WatchCowboysRequest{})
+	if err != nil {
+		return err
+	}
+	// ต่อติดแล้ว ล้างของเก่าที่อาจค้างมาจากช่วงที่หลุด
+	inv.Purge()
+	connected()
+	log.Println("👀 Watching cowboy changes from Duelist")
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		// created ก็ลบด้วย เผื่อมีค่า cache ของ ID เดิมที่เคยถูกลบไปแล้ว
+		inv.Invalidate(ev.Id)
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"api/services/arena/internal/core/domain"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// errorResponse : รูปแบบ JSON ของ error ทุกตัวที่ตอบกลับไป (Details มีเฉพาะตอน request ไม่ตรงเอกสาร)
+type errorResponse struct {
+	Error   string           `json:"error"`
+	Details []fieldViolation `json:"details,omitempty"`
+}
+
+// fieldViolation : field ไหนผิด และผิดเพราะอะไร
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, errorResponse{Error: msg})
+}
+
+// writeServiceError : แปลง Domain Error เป็น HTTP status (ที่ไม่รู้จัก = 500)
+func writeServiceError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrBattleNotFound), errors.Is(err, domain.ErrSeriesNotFound),
+		errors.Is(err, domain.ErrTournamentNotFound), errors.Is(err, domain.ErrRatingNotFound),
+		errors.Is(err, domain.ErrCowboyNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		code = http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidArgument):
+		code = http.StatusBadRequest
+	}
+	writeError(w, code, err.Error())
+}
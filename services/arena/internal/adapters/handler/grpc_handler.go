@@ -0,0 +1,162 @@
+package handler
+
+import (
+	pb "api/proto"
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GrpcHandler : ใช้ ports.ArenaService ตัวเดียวกับ HttpHandler แค่คนละโปรโตคอล
+type GrpcHandler struct {
+	pb.UnimplementedArenaServiceServer
+	service ports.ArenaService
+}
+
+func NewGrpcHandler(s ports.ArenaService) *GrpcHandler {
+	return &GrpcHandler{service: s}
+}
+
+func (h *GrpcHandler) Duel(ctx context.Context, req *pb.DuelRequest) (*pb.BattleResult, error) {
+	result, err := h.service.Duel(ctx, req.Fighter1Id, req.Fighter2Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return battleToProto(result), nil
+}
+
+func (h *GrpcHandler) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	filter := domain.HistoryFilter{
+		Limit:        int(req.Limit),
+		Cursor:       req.Cursor,
+		FighterID:    req.FighterId,
+		OpponentID:   req.OpponentId,
+		WinnerID:     req.WinnerId,
+		TournamentID: uint(req.TournamentId),
+		SeriesID:     uint(req.SeriesId),
+		Sort:         domain.HistorySort(req.Sort),
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	page, err := h.service.GetHistory(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.GetHistoryResponse{NextCursor: page.NextCursor}
+	for i := range page.Battles {
+		resp.Battles = append(resp.Battles, battleToProto(&page.Battles[i]))
+	}
+	return resp, nil
+}
+
+func (h *GrpcHandler) GetBattle(ctx context.Context, req *pb.GetBattleRequest) (*pb.BattleResult, error) {
+	result, err := h.service.GetBattle(ctx, uint(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return battleToProto(result), nil
+}
+
+func (h *GrpcHandler) StreamDuel(req *pb.StreamDuelRequest, stream pb.ArenaService_StreamDuelServer) error {
+	pace := time.Duration(-1)
+	if req.PaceMs != nil {
+		if *req.PaceMs < 0 {
+			return status.Error(codes.InvalidArgument, "pace_ms must be non-negative")
+		}
+		pace = time.Duration(*req.PaceMs) * time.Millisecond
+	}
+
+	// ส่งไม่สำเร็จ (client ตัดสาย) ไม่ต้องหยุด engine ctx ของ stream จะถูกยกเลิกเองและผลยังถูกบันทึก
+	result, err := h.service.DuelLive(stream.Context(), req.Fighter1Id, req.Fighter2Id, pace, func(e domain.BattleEvent) {
+		stream.Send(&pb.DuelUpdate{Update: &pb.DuelUpdate_Event{Event: eventToProto(e)}})
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	return stream.Send(&pb.DuelUpdate{Update: &pb.DuelUpdate_Result{Result: battleToProto(result)}})
+}
+
+func battleToProto(r *domain.BattleResult) *pb.BattleResult {
+	out := &pb.BattleResult{
+		Id:              uint64(r.ID),
+		SeriesId:        uint64(r.SeriesID),
+		TournamentId:    uint64(r.TournamentID),
+		TournamentRound: int32(r.TournamentRound),
+		Fighter1Id:      r.Fighter1ID,
+		Fighter1Name:    r.Fighter1Name,
+		Fighter2Id:      r.Fighter2ID,
+		Fighter2Name:    r.Fighter2Name,
+		Result:          string(r.Result),
+		WinnerId:        r.WinnerID,
+		Winner:          r.Winner,
+		Turns:           int32(r.Turns),
+		MaxTurns:        int32(r.MaxTurns),
+		Seed:            r.Seed,
+		CreatedAt:       timestamppb.New(r.CreatedAt),
+		Fighter1Stats:   snapshotToProto(r.Fighter1Stats),
+		Fighter2Stats:   snapshotToProto(r.Fighter2Stats),
+		Logs:            r.Logs,
+	}
+	if r.SupersededAt != nil {
+		out.SupersededAt = timestamppb.New(*r.SupersededAt)
+	}
+	for _, e := range r.Events {
+		out.Events = append(out.Events, eventToProto(e))
+	}
+	return out
+}
+
+func snapshotToProto(s *domain.FighterSnapshot) *pb.FighterSnapshot {
+	if s == nil {
+		return nil
+	}
+	return &pb.FighterSnapshot{
+		Health:   int32(s.Health),
+		Damage:   int32(s.Damage),
+		Speed:    int32(s.Speed),
+		Accuracy: s.Accuracy,
+		Version:  int32(s.Version),
+	}
+}
+
+func eventToProto(e domain.BattleEvent) *pb.BattleEvent {
+	return &pb.BattleEvent{
+		Type:         string(e.Type),
+		Turn:         int32(e.Turn),
+		AttackerId:   e.AttackerID,
+		AttackerName: e.AttackerName,
+		DefenderId:   e.DefenderID,
+		DefenderName: e.DefenderName,
+		Damage:       int32(e.Damage),
+		AttackerHp:   int32(e.AttackerHP),
+		DefenderHp:   int32(e.DefenderHP),
+		Text:         e.Text(),
+	}
+}
+
+// toStatus : แปลง Domain Error เป็น gRPC status (คู่กับ writeServiceError ฝั่ง HTTP)
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrBattleNotFound), errors.Is(err, domain.ErrSeriesNotFound),
+		errors.Is(err, domain.ErrTournamentNotFound), errors.Is(err, domain.ErrRatingNotFound),
+		errors.Is(err, domain.ErrCowboyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.Aborted, err.Error()) // เหมือนฝั่ง Duelist: client retry ได้ด้วย code เดียวกัน
+	case errors.Is(err, domain.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	pb "api/proto"
+	"api/services/arena/internal/adapters/repository"
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/domain/entity"
+	"api/services/arena/internal/core/ports"
+	"api/services/arena/internal/core/services"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fixedProvider : CowboyProvider ปลอม คืนสำเนาใหม่ทุกครั้ง (engine ลดเลือดตัวที่ได้ไป)
+type fixedProvider map[string]*entity.Cowboy
+
+func (p fixedProvider) GetCowboy(ctx context.Context, id string) (*entity.Cowboy, error) {
+	c, ok := p[id]
+	if !ok {
+		return nil, domain.ErrCowboyNotFound
+	}
+	return c.Clone(), nil
+}
+
+func (p fixedProvider) GetCowboys(ctx context.Context, ids []string) ([]*entity.Cowboy, error) {
+	var out []*entity.Cowboy
+	for _, id := range ids {
+		c, err := p.GetCowboy(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// drawProvider : สองคนที่ยิงเบาจนไม่มีใครล้มก่อนครบเทิร์น
+func drawProvider() fixedProvider {
+	return fixedProvider{
+		"kid": {ID: "kid", Name: "Kid", Health: 100, Damage: 1, Speed: 10, Accuracy: 0.5, Version: 2},
+		"doc": {ID: "doc", Name: "Doc", Health: 100, Damage: 1, Speed: 10, Accuracy: 0.5, Version: 1},
+	}
+}
+
+// dialArena : เปิด GrpcHandler บน bufconn แล้วคืน client ที่ต่อผ่าน gRPC จริง
+func dialArena(t *testing.T, s ports.ArenaService) pb.ArenaServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterArenaServiceServer(srv, NewGrpcHandler(s))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewArenaServiceClient(conn)
+}
+
+func snapshotFromProto(id, name string, s *pb.FighterSnapshot) *entity.Cowboy {
+	return &entity.Cowboy{ID: id, Name: name, Health: int(s.Health), Damage: int(s.Damage), Speed: int(s.Speed), Accuracy: s.Accuracy, Version: int(s.Version)}
+}
+
+func TestGrpcTurnLimitDrawReplays(t *testing.T) {
+	const maxTurns = 3
+	repo := repository.NewMemoryRepository(repository.NewMemoryStore(32))
+	client := dialArena(t, services.NewArenaService(drawProvider(), repo, domain.Rules{MaxTurns: maxTurns}))
+	ctx := context.Background()
+
+	duel, err := client.Duel(ctx, &pb.DuelRequest{Fighter1Id: "kid", Fighter2Id: "doc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if duel.Result != string(domain.ResultDraw) || duel.MaxTurns != maxTurns {
+		t.Fatalf("duel = %s after %d turns with max_turns %d, want a draw at the %d-turn cap", duel.Result, duel.Turns, duel.MaxTurns, maxTurns)
+	}
+
+	// client อ่านกลับมาทาง GetBattle แล้ว replay เองจาก seed + กติกา + ค่าสถานะ ต้องได้ผลเดิมทุกจังหวะ
+	got, err := client.GetBattle(ctx, &pb.GetBattleRequest{Id: duel.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MaxTurns != maxTurns || got.Seed != duel.Seed {
+		t.Fatalf("GetBattle max_turns = %d seed = %d, want %d and %d", got.MaxTurns, got.Seed, maxTurns, duel.Seed)
+	}
+	c1 := snapshotFromProto(got.Fighter1Id, got.Fighter1Name, got.Fighter1Stats)
+	c2 := snapshotFromProto(got.Fighter2Id, got.Fighter2Name, got.Fighter2Stats)
+	replay := domain.SimulateFight(c1, c2, got.Seed, domain.Rules{MaxTurns: int(got.MaxTurns)})
+
+	if string(replay.Result) != got.Result || int32(replay.Turns) != got.Turns || len(replay.Events) != len(got.Events) {
+		t.Fatalf("replay = %s in %d turns with %d events, stored %s in %d turns with %d events",
+			replay.Result, replay.Turns, len(replay.Events), got.Result, got.Turns, len(got.Events))
+	}
+	for i, e := range replay.Events {
+		if want := eventToProto(e); want.Type != got.Events[i].Type || want.Damage != got.Events[i].Damage || want.DefenderHp != got.Events[i].DefenderHp {
+			t.Fatalf("event %d = %+v, stored %+v", i, want, got.Events[i])
+		}
+	}
+}
+
+func TestGrpcBattleCarriesTournamentFields(t *testing.T) {
+	store := repository.NewMemoryStore(32)
+	tournaments := repository.NewMemoryTournamentRepository(store)
+	client := dialArena(t, services.NewArenaService(drawProvider(), repository.NewMemoryRepository(store), domain.Rules{}))
+	ctx := context.Background()
+
+	cowboys := map[string]*entity.Cowboy{}
+	var list []*entity.Cowboy
+	for id, c := range drawProvider() {
+		cowboys[id] = c
+		list = append(list, c)
+	}
+	tour, err := domain.NewTournament("cup", domain.FormatRoundRobin, domain.SeedingAsGiven, list, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tournaments.Create(ctx, tour); err != nil {
+		t.Fatal(err)
+	}
+	// เล่นรอบ 1 แล้วเล่นใหม่: battle แรก (ID 1) ถูกแทน battle ใหม่ (ID 2)
+	for replayFrom := range 2 {
+		if replayFrom > 0 {
+			if err := tour.ResetRound(replayFrom); err != nil {
+				t.Fatal(err)
+			}
+		}
+		battles, err := tour.PlayRound(cowboys, domain.Rules{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tournaments.SaveRound(ctx, tour, battles, replayFrom); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := client.GetHistory(ctx, &pb.GetHistoryRequest{TournamentId: uint64(tour.ID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Battles) != 1 {
+		t.Fatalf("history has %d battles, want only the replayed one", len(page.Battles))
+	}
+	current := page.Battles[0]
+	if current.Id != 2 || current.TournamentRound != 1 || current.SupersededAt != nil {
+		t.Fatalf("history battle %d round %d superseded %v, want battle 2 of round 1 still counted", current.Id, current.TournamentRound, current.SupersededAt)
+	}
+	if len(current.Logs) == 0 || len(current.Logs) != len(current.Events) {
+		t.Fatalf("battle has %d logs for %d events", len(current.Logs), len(current.Events))
+	}
+
+	old, err := client.GetBattle(ctx, &pb.GetBattleRequest{Id: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.TournamentRound != 1 || old.SupersededAt == nil {
+		t.Fatalf("replaced battle round %d superseded %v, want round 1 with superseded_at", old.TournamentRound, old.SupersededAt)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{domain.ErrBattleNotFound, codes.NotFound},
+		{fmt.Errorf("load: %w", domain.ErrCowboyNotFound), codes.NotFound},
+		{domain.ErrTournamentNotFound, codes.NotFound},
+		{fmt.Errorf("%w: bad sort", domain.ErrInvalidArgument), codes.InvalidArgument},
+		{domain.ErrConflict, codes.Aborted},
+		{errors.New("db down"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := status.Code(toStatus(tt.err)); got != tt.want {
+			t.Errorf("toStatus(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
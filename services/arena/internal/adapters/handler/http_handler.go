@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"api/services/arena/internal/core/domain"
 	"api/services/arena/internal/core/ports"
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type HttpHandler struct {
@@ -17,7 +19,7 @@ func NewHttpHandler(s ports.ArenaService) *HttpHandler {
 
 func (h *HttpHandler) HandleDuel(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", 405)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 	var req struct {
@@ -25,43 +27,169 @@ func (h *HttpHandler) HandleDuel(w http.ResponseWriter, r *http.Request) {
 		F2 string `json:"fighter_2"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", 400)
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
 		return
 	}
 
-	result, err := h.service.Duel(req.F1, req.F2)
+	result, err := h.service.Duel(r.Context(), req.F1, req.F2)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		writeServiceError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	writeJSON(w, http.StatusOK, result)
 }
 
 func (h *HttpHandler) HandleHistory(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	// 1. อ่านค่า Query Parameter
+	// 1. อ่านค่า Query Parameter (รูปแบบถูกตรวจตามเอกสาร OpenAPI มาแล้ว)
 	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	tournamentID, _ := strconv.ParseUint(query.Get("tournament_id"), 10, 64)
+	seriesID, _ := strconv.ParseUint(query.Get("series_id"), 10, 64)
 
-	// อ่าน limit (Default เป็น 0 ถ้าไม่ส่งมา)
-	limitStr := query.Get("limit")
-	limit, _ := strconv.Atoi(limitStr)
+	filter := domain.HistoryFilter{
+		Limit:        limit,
+		Cursor:       query.Get("cursor"),
+		FighterID:    query.Get("fighter_id"),
+		OpponentID:   query.Get("opponent_id"),
+		WinnerID:     query.Get("winner_id"),
+		TournamentID: uint(tournamentID),
+		SeriesID:     uint(seriesID),
+		Sort:         domain.HistorySort(query.Get("sort")),
+	}
+	// from / to เป็น RFC 3339 (เช่น 2025-01-31T00:00:00Z)
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := query.Get(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, p.name+" must be an RFC 3339 timestamp")
+				return
+			}
+			*p.dst = t
+		}
+	}
+
+	// 2. เรียก Service พร้อม filter
+	page, err := h.service.GetHistory(r.Context(), filter)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (h *HttpHandler) HandleBattle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// อ่าน {id} จาก path เช่น /battles/42
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid battle id")
+		return
+	}
+
+	battle, err := h.service.GetBattle(r.Context(), uint(id))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, battle)
+}
+
+func (h *HttpHandler) HandleSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var req struct {
+		F1     string `json:"fighter_1"`
+		F2     string `json:"fighter_2"`
+		BestOf int    `json:"best_of"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	series, err := h.service.Series(r.Context(), req.F1, req.F2, req.BestOf)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, series)
+}
+
+func (h *HttpHandler) HandleGetSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid series id")
+		return
+	}
+
+	series, err := h.service.GetSeries(r.Context(), uint(id))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, series)
+}
 
-	// อ่าน fighter_id
-	fighterID := query.Get("fighter_id")
+func (h *HttpHandler) HandleFighterStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	stats, err := h.service.GetFighterStats(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (h *HttpHandler) HandleMatchup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// อ่าน query: fighter_1, fighter_2 (จำเป็น), samples, budget_ms (ไม่ส่ง = ค่า default)
+	query := r.URL.Query()
+	f1, f2 := query.Get("fighter_1"), query.Get("fighter_2")
+	if f1 == "" || f2 == "" {
+		writeError(w, http.StatusBadRequest, "fighter_1 and fighter_2 are required")
+		return
+	}
+	samples, _ := strconv.Atoi(query.Get("samples"))
+	budgetMs, _ := strconv.Atoi(query.Get("budget_ms"))
 
-	// 2. เรียก Service พร้อม parameter
-	history, err := h.service.GetHistory(limit, fighterID)
+	matchup, err := h.service.Matchup(r.Context(), f1, f2, samples, time.Duration(budgetMs)*time.Millisecond)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(history)
+	writeJSON(w, http.StatusOK, matchup)
 }
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"api/services/arena/internal/core/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// ชนิดของข้อความระหว่างถ่ายทอดสด
+const (
+	liveEventMsg  = "event"  // 1 จังหวะในการดวล
+	liveResultMsg = "result" // ผลสุดท้าย (บันทึกแล้ว มี ID)
+	liveErrorMsg  = "error"
+)
+
+// liveMessage : ข้อความที่ส่งออกไป ใช้รูปแบบเดียวกันทั้ง SSE และ WebSocket
+type liveMessage struct {
+	Type   string               `json:"type"`
+	Event  *liveEvent           `json:"event,omitempty"`
+	Result *domain.BattleResult `json:"result,omitempty"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// liveEvent : Event พร้อมข้อความให้คนอ่าน (UI เอาไปแสดงได้เลย)
+type liveEvent struct {
+	domain.BattleEvent
+	Text string `json:"text"`
+}
+
+func eventMessage(e domain.BattleEvent) liveMessage {
+	return liveMessage{Type: liveEventMsg, Event: &liveEvent{BattleEvent: e, Text: e.Text()}}
+}
+
+// parseLiveQuery : อ่าน fighter_1, fighter_2 (จำเป็น) และ pace_ms (ไม่ส่ง = ค่า default, 0 = ไม่เว้นจังหวะ)
+func parseLiveQuery(r *http.Request) (f1, f2 string, pace time.Duration, err error) {
+	query := r.URL.Query()
+	f1, f2 = query.Get("fighter_1"), query.Get("fighter_2")
+	if f1 == "" || f2 == "" {
+		return "", "", 0, errors.New("fighter_1 and fighter_2 are required")
+	}
+
+	pace = -1
+	if v := query.Get("pace_ms"); v != "" {
+		ms, convErr := strconv.Atoi(v)
+		if convErr != nil || ms < 0 {
+			return "", "", 0, errors.New("pace_ms must be a non-negative integer")
+		}
+		pace = time.Duration(ms) * time.Millisecond
+	}
+	return f1, f2, pace, nil
+}
+
+// HandleDuelStream : GET /duel/stream ถ่ายทอดสดแบบ Server-Sent Events
+func (h *HttpHandler) HandleDuelStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	f1, f2, pace, err := parseLiveQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	// ส่ง header ตอนมี Event แรก ถ้าพังก่อนเริ่ม (เช่นหา Cowboy ไม่เจอ) จะได้ตอบ error เป็น JSON ปกติ
+	started := false
+	send := func(msg liveMessage) {
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		data, _ := json.Marshal(msg)
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Type, data)
+		flusher.Flush()
+	}
+
+	result, err := h.service.DuelLive(r.Context(), f1, f2, pace, func(e domain.BattleEvent) {
+		send(eventMessage(e))
+	})
+	if err != nil {
+		if !started {
+			writeServiceError(w, err)
+			return
+		}
+		send(liveMessage{Type: liveErrorMsg, Error: err.Error()})
+		return
+	}
+	send(liveMessage{Type: liveResultMsg, Result: result})
+}
+
+// HandleDuelWebSocket : GET /duel/ws ถ่ายทอดสดแบบ WebSocket (ข้อความ JSON ทีละ liveMessage)
+func (h *HttpHandler) HandleDuelWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	f1, f2, pace, err := parseLiveQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	websocket.Server{Handshake: checkWebSocketOrigin, Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		// หลัง upgrade แล้ว r.Context() จะไม่รู้ว่า client ตัดสาย ต้องคอยอ่านเองแล้ว cancel
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go func() {
+			io.Copy(io.Discard, ws)
+			cancel()
+		}()
+
+		send := func(msg liveMessage) {
+			websocket.JSON.Send(ws, msg)
+		}
+		result, err := h.service.DuelLive(ctx, f1, f2, pace, func(e domain.BattleEvent) {
+			send(eventMessage(e))
+		})
+		if err != nil {
+			send(liveMessage{Type: liveErrorMsg, Error: err.Error()})
+			return
+		}
+		send(liveMessage{Type: liveResultMsg, Result: result})
+	}}.ServeHTTP(w, r)
+}
+
+// checkWebSocketOrigin : ค่า default ของ x/net ตอบ 403 ถ้าไม่มี Origin ทำให้ client ที่ไม่ใช่ browser (Go, CLI, service ภายใน) ต่อไม่ได้
+// ไม่มี Origin = ไม่ใช่ browser ให้ผ่าน ส่วน browser ต้องมาจาก host เดียวกัน กันเว็บอื่นเปิดดวลแทนผู้ใช้
+func checkWebSocketOrigin(config *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host != r.Host {
+		return fmt.Errorf("origin %q is not allowed", origin)
+	}
+	config.Origin = u
+	return nil
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"api/services/arena/internal/adapters/repository"
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/services"
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// dialLive : upgrade /duel/ws ด้วย net/http เอง (client ของ x/net ส่ง Origin เสมอ จำลอง client ที่ไม่ส่งไม่ได้)
+func dialLive(t *testing.T, server *httptest.Server, origin string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest("GET", server.URL+"/duel/ws?fighter_1=kid&fighter_2=doc&pace_ms=0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// readFrame : อ่าน frame จาก server (ไม่ mask และ JSON.Send ส่งข้อความละ frame เดียว)
+func readFrame(r *bufio.Reader) ([]byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	n := uint64(head[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	payload := make([]byte, n)
+	_, err := io.ReadFull(r, payload)
+	return payload, err
+}
+
+func TestDuelWebSocket(t *testing.T) {
+	const maxTurns = 5
+	repo := repository.NewMemoryRepository(repository.NewMemoryStore(32))
+	h := NewHttpHandler(services.NewArenaService(drawProvider(), repo, domain.Rules{MaxTurns: maxTurns}))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/duel/ws", h.HandleDuelWebSocket)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		origin   string
+		wantCode int
+	}{
+		{"no origin (Go, CLI, internal services)", "", http.StatusSwitchingProtocols},
+		{"same-origin browser", server.URL, http.StatusSwitchingProtocols},
+		{"cross-site browser", "http://evil.example", http.StatusForbidden},
+		{"null origin", "null", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := dialLive(t, server, tt.origin)
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("handshake status = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusSwitchingProtocols {
+				return
+			}
+
+			// อ่านทุกจังหวะจนถึงผลสุดท้าย
+			r := bufio.NewReader(resp.Body)
+			var events int
+			for {
+				payload, err := readFrame(r)
+				if err != nil {
+					t.Fatalf("stream ended after %d events without a result: %v", events, err)
+				}
+				var msg liveMessage
+				if err := json.Unmarshal(payload, &msg); err != nil {
+					t.Fatal(err)
+				}
+				switch msg.Type {
+				case liveEventMsg:
+					if msg.Event == nil || msg.Event.Text == "" {
+						t.Fatalf("event message without event text: %s", payload)
+					}
+					events++
+					continue
+				case liveResultMsg:
+					res := msg.Result
+					if res.ID == 0 || res.MaxTurns != maxTurns || len(res.Events) != events {
+						t.Fatalf("result id %d max_turns %d with %d events, streamed %d", res.ID, res.MaxTurns, len(res.Events), events)
+					}
+				default:
+					t.Fatalf("unexpected message: %s", payload)
+				}
+				break
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// openAPISpec : เอกสาร OpenAPI ของทุก route (แก้ route เมื่อไหร่ต้องแก้ไฟล์นี้ด้วย)
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// distinctFieldsExt : extension ระดับ operation บอกว่า field (query หรือ body) ในรายการนี้ต้องไม่ซ้ำกัน
+const distinctFieldsExt = "x-distinct-fields"
+
+// OpenAPI : เสิร์ฟเอกสาร และตรวจ request ตามเอกสารก่อนถึง handler
+type OpenAPI struct {
+	router routers.Router
+}
+
+func NewOpenAPI() (*OpenAPI, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAPI{router: router}, nil
+}
+
+// HandleSpec : GET /openapi.json
+func (o *OpenAPI) HandleSpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+// Validate : middleware ตรวจ path / query / body ตามเอกสาร ไม่ผ่าน = 400 พร้อม details ราย field
+func (o *OpenAPI) Validate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := o.router.FindRoute(r)
+		if err != nil {
+			// ไม่มีในเอกสาร (path / method ผิด) ปล่อยให้ handler ตอบ 404 / 405 เอง
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// body ทุกตัวของ API นี้เป็น JSON ไม่ส่ง Content-Type มา = ถือว่าเป็น JSON (ส่ง type อื่นมาชัดๆ ถึงจะ 400)
+		if route.Operation.RequestBody != nil && r.Header.Get("Content-Type") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Content-Type", "application/json")
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{MultiError: true},
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{
+				Error:   "request does not match the API specification",
+				Details: collectViolations(err, ""),
+			})
+			return
+		}
+		if vs := checkDistinct(r, route.Operation); len(vs) > 0 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "request does not match the API specification", Details: vs})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// collectViolations : แตก error ของ kin-openapi ออกเป็นราย field
+// ไล่ทีละชั้นเอง (ไม่ใช้ errors.As) เพราะชื่อ field อยู่ในชั้นนอก ส่วนเหตุผลอยู่ชั้นใน
+func collectViolations(err error, field string) []fieldViolation {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var vs []fieldViolation
+		for _, inner := range e {
+			vs = append(vs, collectViolations(inner, field)...)
+		}
+		return vs
+
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		} else if e.RequestBody != nil && field == "" {
+			field = "body"
+		}
+		if e.Err == nil {
+			return []fieldViolation{{Field: field, Description: e.Reason}}
+		}
+		return collectViolations(e.Err, field)
+
+	case *openapi3.SchemaError:
+		if path := strings.Trim(strings.Join(e.JSONPointer(), "."), "."); path != "" {
+			field = path
+		}
+		return []fieldViolation{{Field: field, Description: e.Reason}}
+
+	case *openapi3filter.ParseError:
+		return []fieldViolation{{Field: field, Description: e.Error()}}
+	}
+	return []fieldViolation{{Field: field, Description: err.Error()}}
+}
+
+// checkDistinct : ตรวจ x-distinct-fields (JSON Schema เทียบค่าระหว่าง field ไม่ได้)
+func checkDistinct(r *http.Request, op *openapi3.Operation) []fieldViolation {
+	names, ok := op.Extensions[distinctFieldsExt].([]any)
+	if !ok {
+		return nil
+	}
+
+	// body ถูกอ่านไปตอน validate แล้ว (kin-openapi ใส่กลับให้) อ่านอีกรอบแล้วใส่คืน
+	var body map[string]any
+	if r.Body != nil && r.Body != http.NoBody {
+		data, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		json.Unmarshal(data, &body)
+	}
+	value := func(name string) any {
+		if v, ok := body[name]; ok {
+			return v
+		}
+		return r.URL.Query().Get(name)
+	}
+
+	var vs []fieldViolation
+	seen := make(map[any]string, len(names))
+	for _, n := range names {
+		name, _ := n.(string)
+		v := value(name)
+		if first, dup := seen[v]; dup {
+			vs = append(vs, fieldViolation{Field: name, Description: "must be different from " + first})
+			continue
+		}
+		seen[v] = name
+	}
+	return vs
+}
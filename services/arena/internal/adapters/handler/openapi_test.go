@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateRequests(t *testing.T) {
+	o, err := NewOpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reached bool
+	h := o.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	duel := `{"fighter_1":"kid","fighter_2":"doc"}`
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{"duel with JSON content type", "POST", "/duel", "application/json", duel, http.StatusOK},
+		{"duel without content type", "POST", "/duel", "", duel, http.StatusOK},
+		{"duel with another content type", "POST", "/duel", "text/plain", duel, http.StatusBadRequest},
+		{"duel without body", "POST", "/duel", "", "", http.StatusBadRequest},
+		{"history limit omitted", "GET", "/history", "", "", http.StatusOK},
+		{"history limit 0 uses the default", "GET", "/history?limit=0", "", "", http.StatusOK},
+		{"history limit at max", "GET", "/history?limit=100", "", "", http.StatusOK},
+		{"history limit negative", "GET", "/history?limit=-1", "", "", http.StatusBadRequest},
+		{"history limit over max", "GET", "/history?limit=101", "", "", http.StatusBadRequest},
+		{"leaderboard limit 0 uses the default", "GET", "/leaderboard?limit=0", "", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached = false
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if reached != (tt.wantCode == http.StatusOK) {
+				t.Fatalf("handler reached = %v", reached)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"net/http"
+	"strconv"
+)
+
+type RatingHandler struct {
+	service ports.RatingService
+}
+
+func NewRatingHandler(s ports.RatingService) *RatingHandler {
+	return &RatingHandler{service: s}
+}
+
+func (h *RatingHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// อ่าน query: limit, offset, min_games, name (ไม่ส่ง = ใช้ค่า default)
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	minGames, _ := strconv.Atoi(query.Get("min_games"))
+
+	filter := domain.LeaderboardFilter{
+		Limit:        limit,
+		Offset:       offset,
+		MinGames:     minGames,
+		NameContains: query.Get("name"),
+	}
+	page, err := h.service.Leaderboard(r.Context(), filter)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (h *RatingHandler) HandleCowboyRating(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	rating, history, err := h.service.GetRating(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		*domain.Rating
+		History []domain.RatingChange `json:"history"`
+	}{Rating: rating, History: history})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type TournamentHandler struct {
+	service ports.TournamentService
+}
+
+func NewTournamentHandler(s ports.TournamentService) *TournamentHandler {
+	return &TournamentHandler{service: s}
+}
+
+// tournamentResponse : สายการแข่ง + ตารางคะแนน (คำนวณจากผลทุกคู่)
+type tournamentResponse struct {
+	*domain.Tournament
+	Standings []domain.Standing `json:"standings"`
+}
+
+func writeTournament(w http.ResponseWriter, code int, t *domain.Tournament) {
+	writeJSON(w, code, tournamentResponse{Tournament: t, Standings: t.Standings()})
+}
+
+func (h *TournamentHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	var req struct {
+		Name      string   `json:"name"`
+		Format    string   `json:"format"`  // single_elimination (default) / round_robin
+		Seeding   string   `json:"seeding"` // as_given (default) / random
+		CowboyIDs []string `json:"cowboy_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	t, err := h.service.Create(r.Context(), req.Name, domain.TournamentFormat(req.Format), domain.Seeding(req.Seeding), req.CowboyIDs)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeTournament(w, http.StatusCreated, t)
+}
+
+func (h *TournamentHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := tournamentID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeTournament(w, http.StatusOK, t)
+}
+
+func (h *TournamentHandler) HandleAdvance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := tournamentID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.service.Advance(r.Context(), id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeTournament(w, http.StatusOK, t)
+}
+
+func (h *TournamentHandler) HandleReplayRound(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	id, ok := tournamentID(w, r)
+	if !ok {
+		return
+	}
+	round, err := strconv.Atoi(r.PathValue("round"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid round")
+		return
+	}
+
+	t, err := h.service.ReplayRound(r.Context(), id, round)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeTournament(w, http.StatusOK, t)
+}
+
+// tournamentID : อ่าน {id} จาก path (ตอบ 400 ให้เองถ้าผิดรูปแบบ)
+func tournamentID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid tournament id")
+		return 0, false
+	}
+	return uint(id), true
+}
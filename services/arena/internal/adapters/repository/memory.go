@@ -0,0 +1,439 @@
+package repository
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore : ข้อมูลทั้งหมดของ Arena ในหน่วยความจำ (สำหรับ dev / test ปิดโปรแกรมแล้วหาย)
+// repository ทุกตัวที่สร้างจาก store เดียวกันเห็นข้อมูลชุดเดียวกัน เหมือนใช้ DB ตัวเดียวกัน
+type MemoryStore struct {
+	mu            sync.RWMutex
+	k             float64               // ค่า K ของ Elo ที่ใช้ตอนบันทึก battle
+	battles       []domain.BattleResult // ID = index + 1
+	series        []domain.Series       // ID = index + 1 (ไม่เก็บ Games ซ้ำ ดึงจาก battles)
+	tournaments   []domain.Tournament   // ID = index + 1
+	nextMatchID   uint
+	ratings       map[string]domain.Rating
+	ratingHistory []domain.RatingChange
+}
+
+func NewMemoryStore(k float64) *MemoryStore {
+	return &MemoryStore{k: k, ratings: make(map[string]domain.Rating)}
+}
+
+// createBattle : เหมือน createBattle ฝั่ง gorm บันทึก battle แล้วอัปเดตคะแนน (ต้องถือ lock อยู่แล้ว)
+func (s *MemoryStore) createBattle(res *domain.BattleResult) {
+	res.ID = uint(len(s.battles) + 1)
+	res.CreatedAt = time.Now()
+	s.battles = append(s.battles, cloneBattle(*res))
+	s.applyRatings(res)
+}
+
+// applyRatings : เหมือน applyRatings ฝั่ง gorm (lock ของ store กันการอัปเดตซ้อนอยู่แล้ว)
+func (s *MemoryStore) applyRatings(res *domain.BattleResult) {
+	r1, r2 := s.rating(res.Fighter1ID, res.Fighter1Name), s.rating(res.Fighter2ID, res.Fighter2Name)
+	c1, c2 := domain.ApplyElo(r1, r2, res, s.k)
+
+	now := time.Now()
+	for _, rt := range []*domain.Rating{r1, r2} {
+		rt.UpdatedAt = now
+		s.ratings[rt.CowboyID] = *rt
+	}
+	for _, c := range []domain.RatingChange{c1, c2} {
+		c.CreatedAt = now
+		s.ratingHistory = append(s.ratingHistory, c)
+	}
+}
+
+// supersedeBattles : เหมือน supersedeBattles ฝั่ง gorm (ต้องถือ lock อยู่แล้ว)
+func (s *MemoryStore) supersedeBattles(tournamentID uint, fromRound int) {
+	now := time.Now()
+	for i := len(s.battles) - 1; i >= 0; i-- {
+		b := &s.battles[i]
+		if b.TournamentID != tournamentID || b.TournamentRound < fromRound || b.SupersededAt != nil {
+			continue
+		}
+		b.SupersededAt = &now
+		s.revertRatings(b)
+	}
+}
+
+// revertRatings : เหมือน revertRatings ฝั่ง gorm ย้อนตามประวัติคะแนนของ battle นี้
+func (s *MemoryStore) revertRatings(res *domain.BattleResult) {
+	now := time.Now()
+	for _, c := range s.ratingHistory {
+		if c.BattleID != res.ID {
+			continue
+		}
+		rt := s.rating(c.CowboyID, "")
+		rev := domain.RevertElo(rt, c, res)
+		rev.CreatedAt = now
+		rt.UpdatedAt = now
+		s.ratings[rt.CowboyID] = *rt
+		s.ratingHistory = append(s.ratingHistory, rev)
+	}
+}
+
+// rating : สำเนาคะแนนปัจจุบัน (ยังไม่มี = คะแนนเริ่มต้น)
+func (s *MemoryStore) rating(cowboyID, name string) *domain.Rating {
+	if rt, ok := s.ratings[cowboyID]; ok {
+		return &rt
+	}
+	return domain.NewRating(cowboyID, name)
+}
+
+// cloneBattle : สำเนาที่ไม่แชร์ slice / pointer กับตัวเดิม
+func cloneBattle(b domain.BattleResult) domain.BattleResult {
+	b.Events = append([]domain.BattleEvent(nil), b.Events...)
+	b.Logs = append([]string(nil), b.Logs...)
+	if b.SupersededAt != nil {
+		at := *b.SupersededAt
+		b.SupersededAt = &at
+	}
+	if b.Fighter1Stats != nil {
+		st := *b.Fighter1Stats
+		b.Fighter1Stats = &st
+	}
+	if b.Fighter2Stats != nil {
+		st := *b.Fighter2Stats
+		b.Fighter2Stats = &st
+	}
+	return b
+}
+
+type memoryRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryRepository(store *MemoryStore) ports.BattleRepository {
+	return &memoryRepo{store: store}
+}
+
+func (r *memoryRepo) Save(ctx context.Context, res *domain.BattleResult) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.createBattle(res)
+	return nil
+}
+
+func (r *memoryRepo) FindByID(ctx context.Context, id uint) (*domain.BattleResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if id == 0 || int(id) > len(r.store.battles) {
+		return nil, domain.ErrBattleNotFound
+	}
+	res := cloneBattle(r.store.battles[id-1])
+	return &res, nil
+}
+
+func (r *memoryRepo) GetHistory(ctx context.Context, filter domain.HistoryFilter, after *domain.HistoryCursor) ([]domain.BattleResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var results []domain.BattleResult
+	for _, b := range r.store.battles {
+		if matchesHistory(&b, filter) && afterCursor(&b, filter.Sort, after) {
+			results = append(results, b)
+		}
+	}
+
+	// เรียงแบบเดียวกับ query ฝั่ง mysql
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch filter.Sort {
+		case domain.SortOldest:
+			return a.ID < b.ID
+		case domain.SortLongest:
+			return a.Turns > b.Turns || (a.Turns == b.Turns && a.ID > b.ID)
+		case domain.SortShortest:
+			return a.Turns < b.Turns || (a.Turns == b.Turns && a.ID < b.ID)
+		}
+		return a.ID > b.ID
+	})
+	if len(results) > filter.Limit {
+		results = results[:filter.Limit]
+	}
+	for i := range results {
+		results[i] = cloneBattle(results[i])
+	}
+	return results, nil
+}
+
+func matchesHistory(b *domain.BattleResult, f domain.HistoryFilter) bool {
+	if b.SupersededAt != nil {
+		return false
+	}
+	switch {
+	case f.FighterID != "" && f.OpponentID != "":
+		if !(b.Fighter1ID == f.FighterID && b.Fighter2ID == f.OpponentID) &&
+			!(b.Fighter1ID == f.OpponentID && b.Fighter2ID == f.FighterID) {
+			return false
+		}
+	case f.FighterID != "":
+		if b.Fighter1ID != f.FighterID && b.Fighter2ID != f.FighterID {
+			return false
+		}
+	}
+	if f.WinnerID != "" && b.WinnerID != f.WinnerID {
+		return false
+	}
+	if f.TournamentID != 0 && b.TournamentID != f.TournamentID {
+		return false
+	}
+	if f.SeriesID != 0 && b.SeriesID != f.SeriesID {
+		return false
+	}
+	if !f.From.IsZero() && b.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !b.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+func afterCursor(b *domain.BattleResult, s domain.HistorySort, after *domain.HistoryCursor) bool {
+	if after == nil {
+		return true
+	}
+	switch s {
+	case domain.SortOldest:
+		return b.ID > after.ID
+	case domain.SortLongest:
+		return b.Turns < after.Turns || (b.Turns == after.Turns && b.ID < after.ID)
+	case domain.SortShortest:
+		return b.Turns > after.Turns || (b.Turns == after.Turns && b.ID > after.ID)
+	}
+	return b.ID < after.ID
+}
+
+func (r *memoryRepo) SaveSeries(ctx context.Context, s *domain.Series) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s.ID = uint(len(r.store.series) + 1)
+	s.CreatedAt = time.Now()
+	for i := range s.Games {
+		s.Games[i].SeriesID = s.ID
+		r.store.createBattle(&s.Games[i])
+	}
+
+	stored := *s
+	stored.Games = nil
+	r.store.series = append(r.store.series, stored)
+	return nil
+}
+
+func (r *memoryRepo) FindSeries(ctx context.Context, id uint) (*domain.Series, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if id == 0 || int(id) > len(r.store.series) {
+		return nil, domain.ErrSeriesNotFound
+	}
+	s := r.store.series[id-1]
+	for _, b := range r.store.battles {
+		if b.SeriesID == id {
+			s.Games = append(s.Games, cloneBattle(b))
+		}
+	}
+	return &s, nil
+}
+
+// FighterStats : รวมแบบเดียวกับ aggregate query ฝั่ง mysql (GROUP BY คู่ต่อสู้ เรียงตามจำนวนนัด)
+func (r *memoryRepo) FighterStats(ctx context.Context, cowboyID string) (*domain.FighterStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byOpponent := make(map[string]*domain.HeadToHead)
+	var order []string
+	for i := range r.store.battles {
+		b := &r.store.battles[i]
+		if (b.Fighter1ID != cowboyID && b.Fighter2ID != cowboyID) || b.SupersededAt != nil {
+			continue
+		}
+
+		mine, theirs := b.Totals()
+		oppID, oppName := b.Fighter2ID, b.Fighter2Name
+		if b.Fighter1ID != cowboyID {
+			mine, theirs = theirs, mine
+			oppID, oppName = b.Fighter1ID, b.Fighter1Name
+		}
+
+		h, ok := byOpponent[oppID]
+		if !ok {
+			h = &domain.HeadToHead{OpponentID: oppID}
+			byOpponent[oppID] = h
+			order = append(order, oppID)
+		}
+		if oppName > h.OpponentName { // MAX(name) แบบฝั่ง SQL
+			h.OpponentName = oppName
+		}
+		h.Battles++
+		switch {
+		case b.WinnerID == cowboyID:
+			h.Wins++
+		case b.IsDraw():
+			h.Draws++
+		case b.WinnerID != "":
+			h.Losses++
+		}
+		h.Turns += b.Turns
+		h.DamageDealt += mine.DamageDealt
+		h.DamageTaken += theirs.DamageDealt
+		h.Shots += mine.Shots
+		h.Hits += mine.Hits
+	}
+
+	rows := make([]domain.HeadToHead, 0, len(order))
+	for _, id := range order {
+		rows = append(rows, *byOpponent[id])
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Battles > rows[j].Battles })
+	return domain.SummarizeStats(cowboyID, rows), nil
+}
+
+type memoryTournamentRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryTournamentRepository(store *MemoryStore) ports.TournamentRepository {
+	return &memoryTournamentRepo{store: store}
+}
+
+// cloneTournament : สำเนาที่ไม่แชร์ slice กับตัวเดิม
+func cloneTournament(t domain.Tournament) domain.Tournament {
+	t.Participants = append([]domain.Participant(nil), t.Participants...)
+	t.Matches = append([]domain.TournamentMatch(nil), t.Matches...)
+	return t
+}
+
+// assignMatchIDs : เหมือน saveMatches ฝั่ง gorm (คู่ใหม่ได้ ID ใหม่ ต้องถือ lock อยู่แล้ว)
+func (s *MemoryStore) assignMatchIDs(t *domain.Tournament) {
+	for i := range t.Matches {
+		if t.Matches[i].ID == 0 {
+			s.nextMatchID++
+			t.Matches[i].ID = s.nextMatchID
+		}
+	}
+}
+
+func (r *memoryTournamentRepo) Create(ctx context.Context, t *domain.Tournament) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t.ID = uint(len(r.store.tournaments) + 1)
+	t.Version = 1
+	t.CreatedAt = time.Now()
+	r.store.assignMatchIDs(t)
+	r.store.tournaments = append(r.store.tournaments, cloneTournament(*t))
+	return nil
+}
+
+func (r *memoryTournamentRepo) FindByID(ctx context.Context, id uint) (*domain.Tournament, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if id == 0 || int(id) > len(r.store.tournaments) {
+		return nil, domain.ErrTournamentNotFound
+	}
+	t := cloneTournament(r.store.tournaments[id-1])
+	sort.SliceStable(t.Matches, func(i, j int) bool {
+		a, b := t.Matches[i], t.Matches[j]
+		return a.Round < b.Round || (a.Round == b.Round && a.Slot < b.Slot)
+	})
+	return &t, nil
+}
+
+func (r *memoryTournamentRepo) SaveRound(ctx context.Context, t *domain.Tournament, battles []domain.RoundBattle, replayFrom int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if t.ID == 0 || int(t.ID) > len(r.store.tournaments) {
+		return domain.ErrTournamentNotFound
+	}
+	if r.store.tournaments[t.ID-1].Version != t.Version {
+		return fmt.Errorf("%w: tournament %d was changed by another request, reload and try again", domain.ErrConflict, t.ID)
+	}
+	if replayFrom > 0 {
+		r.store.supersedeBattles(t.ID, replayFrom)
+	}
+	for i := range battles {
+		rb := &battles[i]
+		rb.Battle.TournamentID = t.ID
+		r.store.createBattle(&rb.Battle)
+		rb.Match.BattleID = rb.Battle.ID
+	}
+	r.store.assignMatchIDs(t)
+	t.Version++
+	r.store.tournaments[t.ID-1] = cloneTournament(*t)
+	return nil
+}
+
+type memoryRatingRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryRatingRepository(store *MemoryStore) ports.RatingRepository {
+	return &memoryRatingRepo{store: store}
+}
+
+func (r *memoryRatingRepo) FindRating(ctx context.Context, cowboyID string) (*domain.Rating, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rt, ok := r.store.ratings[cowboyID]
+	if !ok {
+		return nil, nil
+	}
+	return &rt, nil
+}
+
+func (r *memoryRatingRepo) Leaderboard(ctx context.Context, filter domain.LeaderboardFilter) ([]domain.Rating, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	needle := strings.ToLower(filter.NameContains)
+	var all []domain.Rating
+	for _, rt := range r.store.ratings {
+		if rt.Games >= filter.MinGames && strings.Contains(strings.ToLower(rt.Name), needle) {
+			all = append(all, rt)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Rating != all[j].Rating {
+			return all[i].Rating > all[j].Rating
+		}
+		return all[i].CowboyID < all[j].CowboyID
+	})
+
+	ratings := make([]domain.Rating, 0, filter.Limit)
+	for i := filter.Offset; i < len(all) && len(ratings) < filter.Limit; i++ {
+		rt := all[i]
+		rt.Rank = i + 1
+		ratings = append(ratings, rt)
+	}
+	return ratings, nil
+}
+
+func (r *memoryRatingRepo) History(ctx context.Context, cowboyID string, limit int) ([]domain.RatingChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// ใหม่สุดก่อน (เหมือน ORDER BY id DESC)
+	changes := make([]domain.RatingChange, 0, limit)
+	for i := len(r.store.ratingHistory) - 1; i >= 0 && len(changes) < limit; i-- {
+		if c := r.store.ratingHistory[i]; c.CowboyID == cowboyID {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
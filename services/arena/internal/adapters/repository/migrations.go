@@ -0,0 +1,599 @@
+package repository
+
+import (
+	"api/pkg/database"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migrations : schema ของ Arena เรียงตาม version (เพิ่มต่อท้ายเท่านั้น ห้ามแก้ตัวที่ปล่อยไปแล้ว)
+// struct ในแต่ละ migration คือหน้าตาตารางณ ตอนนั้น แยกจาก model ที่ใช้งานจริง
+// struct ของ battle_models มีเฉพาะ column ที่ขั้นนั้นแตะ (gorm ใช้แค่นิยามของ column ที่อ้างถึง)
+// DB เดิมที่เคยสร้างด้วย AutoMigrate จะข้ามส่วนที่มีอยู่แล้ว แล้วเดินต่อจนครบทุกขั้น
+func Migrations() []database.Migration {
+	return []database.Migration{
+		{
+			// baseline: ตารางแบบเดิมก่อนมี migration (ชื่อ battle_models เพราะ battleModel ไม่ได้ตั้ง TableName)
+			Version: 1,
+			Name:    "create_battles",
+			Up: func(tx *gorm.DB) error {
+				return database.CreateTable(tx, &battleV1{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("battle_models")
+			},
+		},
+		{
+			// record เก่าได้ result = win จากค่า default (ตอนนั้นยังเสมอไม่ได้)
+			Version: 2,
+			Name:    "add_battle_events",
+			Up: func(tx *gorm.DB) error {
+				return database.AddColumns(tx, &battleV2{}, battleV2Columns...)
+			},
+			Down: func(tx *gorm.DB) error {
+				return database.DropColumns(tx, &battleV2{}, battleV2Columns...)
+			},
+		},
+		{
+			Version: 3,
+			Name:    "create_series",
+			Up: func(tx *gorm.DB) error {
+				if err := database.CreateTable(tx, &seriesV3{}); err != nil {
+					return err
+				}
+				if err := database.AddColumns(tx, &battleV3{}, "series_id"); err != nil {
+					return err
+				}
+				return database.CreateIndexes(tx, &battleV3{}, "idx_battle_models_series_id")
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := database.DropIndexes(tx, &battleV3{}, "idx_battle_models_series_id"); err != nil {
+					return err
+				}
+				if err := database.DropColumns(tx, &battleV3{}, "series_id"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable("series")
+			},
+		},
+		{
+			Version: 4,
+			Name:    "create_tournaments",
+			Up: func(tx *gorm.DB) error {
+				if err := database.CreateTable(tx, &tournamentV4{}, &tournamentMatchV4{}); err != nil {
+					return err
+				}
+				if err := database.AddColumns(tx, &battleV4{}, "tournament_id"); err != nil {
+					return err
+				}
+				return database.CreateIndexes(tx, &battleV4{}, "idx_battle_models_tournament_id")
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := database.DropIndexes(tx, &battleV4{}, "idx_battle_models_tournament_id"); err != nil {
+					return err
+				}
+				if err := database.DropColumns(tx, &battleV4{}, "tournament_id"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable("tournaments", "tournament_matches")
+			},
+		},
+		{
+			Version: 5,
+			Name:    "create_ratings",
+			Up: func(tx *gorm.DB) error {
+				return database.CreateTable(tx, &ratingV5{}, &ratingHistoryV5{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("ratings", "rating_history")
+			},
+		},
+		{
+			// ยอดรวมต่อฝ่าย: record ที่มี Events แล้วคำนวณย้อนหลังให้ (record ที่มีแต่ Logs ยังเป็น 0)
+			Version: 6,
+			Name:    "add_battle_totals",
+			Up: func(tx *gorm.DB) error {
+				if err := database.AddColumns(tx, &battleV6{}, battleV6Columns...); err != nil {
+					return err
+				}
+				return backfillBattleTotals(tx)
+			},
+			Down: func(tx *gorm.DB) error {
+				return database.DropColumns(tx, &battleV6{}, battleV6Columns...)
+			},
+		},
+		{
+			// MySQL ทำ index บน longtext ไม่ได้ ต้องจำกัดขนาด column ก่อน
+			Version: 7,
+			Name:    "add_battle_history_indexes",
+			Up: func(tx *gorm.DB) error {
+				if err := database.AlterColumns(tx, &battleV7{}, "fighter1_id", "fighter2_id", "winner_id"); err != nil {
+					return err
+				}
+				return database.CreateIndexes(tx, &battleV7{}, battleV7Indexes...)
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := database.DropIndexes(tx, &battleV7{}, battleV7Indexes...); err != nil {
+					return err
+				}
+				return database.AlterColumns(tx, &battleV2{}, "fighter1_id", "fighter2_id", "winner_id")
+			},
+		},
+		{
+			Version: 8,
+			Name:    "add_battle_fighter_snapshots",
+			Up: func(tx *gorm.DB) error {
+				return database.AddColumns(tx, &battleV8{}, battleV8Columns...)
+			},
+			Down: func(tx *gorm.DB) error {
+				return database.DropColumns(tx, &battleV8{}, battleV8Columns...)
+			},
+		},
+		{
+			// record ก่อนมี Events มีแค่ Logs: แกะชื่อ / ผู้ชนะ / จำนวนเทิร์น / ยอดรวมกลับมาจากข้อความ
+			// ไม่งั้นสถิติจะนับชัยชนะเก่าเป็นแพ้ (winner_id ว่าง)
+			Version: 9,
+			Name:    "backfill_legacy_battles",
+			Up:      backfillLegacyBattles,
+			Down: func(tx *gorm.DB) error {
+				return nil // เติมข้อมูลอย่างเดียว ไม่มีอะไรต้องย้อน
+			},
+		},
+		{
+			// เล่นรอบทัวร์นาเมนต์ใหม่: battle รู้รอบของตัวเอง / ถูกแทนที่ได้, สายมี version กันบันทึกทับกัน
+			Version: 10,
+			Name:    "add_tournament_replays",
+			Up: func(tx *gorm.DB) error {
+				if err := database.AddColumns(tx, &battleV10{}, "tournament_round", "superseded_at"); err != nil {
+					return err
+				}
+				if err := database.AddColumns(tx, &tournamentV10{}, "version"); err != nil {
+					return err
+				}
+				if err := database.AddColumns(tx, &tournamentMatchV10{}, "replays"); err != nil {
+					return err
+				}
+				return backfillTournamentRounds(tx)
+			},
+			Down: func(tx *gorm.DB) error {
+				// battle ที่ถูกแทนที่ไปแล้ว (คะแนนย้อนไปแล้ว) ต้องหายจริง ไม่งั้นย้อนแล้วจะกลับมาอยู่ในประวัติ
+				if err := tx.Exec("DELETE FROM battle_models WHERE superseded_at IS NOT NULL").Error; err != nil {
+					return err
+				}
+				if err := database.DropColumns(tx, &tournamentMatchV10{}, "replays"); err != nil {
+					return err
+				}
+				if err := database.DropColumns(tx, &tournamentV10{}, "version"); err != nil {
+					return err
+				}
+				return database.DropColumns(tx, &battleV10{}, "tournament_round", "superseded_at")
+			},
+		},
+		{
+			// record เก่าไม่รู้ว่าใช้ MaxTurns เท่าไร (config เปลี่ยนได้) เลยปล่อยเป็น 0
+			Version: 11,
+			Name:    "add_battle_rules",
+			Up: func(tx *gorm.DB) error {
+				return database.AddColumns(tx, &battleV11{}, "max_turns")
+			},
+			Down: func(tx *gorm.DB) error {
+				return database.DropColumns(tx, &battleV11{}, "max_turns")
+			},
+		},
+	}
+}
+
+type battleV1 struct {
+	ID         uint `gorm:"primaryKey"`
+	Fighter1ID string
+	Fighter2ID string
+	Winner     string
+	Logs       string `gorm:"type:text"`
+	CreatedAt  time.Time
+}
+
+func (battleV1) TableName() string {
+	return "battle_models"
+}
+
+type battleV2 struct {
+	Fighter1ID   string
+	Fighter1Name string
+	Fighter2ID   string
+	Fighter2Name string
+	Result       string `gorm:"size:16;default:win"`
+	WinnerID     string
+	Turns        int
+	Events       string `gorm:"type:text"`
+	Seed         int64
+}
+
+func (battleV2) TableName() string {
+	return "battle_models"
+}
+
+var battleV2Columns = []string{"fighter1_name", "fighter2_name", "result", "winner_id", "turns", "events", "seed"}
+
+type seriesV3 struct {
+	ID           uint `gorm:"primaryKey"`
+	Fighter1ID   string
+	Fighter1Name string
+	Fighter2ID   string
+	Fighter2Name string
+	BestOf       int
+	Fighter1Wins int
+	Fighter2Wins int
+	Draws        int
+	Result       string `gorm:"size:16"`
+	WinnerID     string
+	Winner       string
+	Seed         int64
+	CreatedAt    time.Time
+}
+
+func (seriesV3) TableName() string {
+	return "series"
+}
+
+type battleV3 struct {
+	SeriesID *uint `gorm:"index"`
+}
+
+func (battleV3) TableName() string {
+	return "battle_models"
+}
+
+type tournamentV4 struct {
+	ID           uint `gorm:"primaryKey"`
+	Name         string
+	Format       string `gorm:"size:32"`
+	Seeding      string `gorm:"size:32"`
+	Status       string `gorm:"size:16"`
+	Seed         int64
+	CurrentRound int
+	TotalRounds  int
+	WinnerID     string
+	Winner       string
+	Participants string `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (tournamentV4) TableName() string {
+	return "tournaments"
+}
+
+type tournamentMatchV4 struct {
+	ID           uint `gorm:"primaryKey"`
+	TournamentID uint `gorm:"index"`
+	Round        int
+	Slot         int
+	Fighter1ID   string
+	Fighter2ID   string
+	WinnerID     string
+	BattleID     uint
+	Status       string `gorm:"size:16"`
+}
+
+func (tournamentMatchV4) TableName() string {
+	return "tournament_matches"
+}
+
+type battleV4 struct {
+	TournamentID *uint `gorm:"index"`
+}
+
+func (battleV4) TableName() string {
+	return "battle_models"
+}
+
+type ratingV5 struct {
+	CowboyID  string `gorm:"primaryKey"`
+	Name      string
+	Rating    float64 `gorm:"index"`
+	Games     int
+	Wins      int
+	Losses    int
+	Draws     int
+	UpdatedAt time.Time
+}
+
+func (ratingV5) TableName() string {
+	return "ratings"
+}
+
+type ratingHistoryV5 struct {
+	ID         uint   `gorm:"primaryKey"`
+	CowboyID   string `gorm:"index"`
+	BattleID   uint
+	OpponentID string
+	Before     float64
+	After      float64
+	Delta      float64
+	CreatedAt  time.Time
+}
+
+func (ratingHistoryV5) TableName() string {
+	return "rating_history"
+}
+
+type battleV6 struct {
+	ID             uint `gorm:"primaryKey"`
+	Fighter1ID     string
+	Events         string
+	Fighter1Damage int
+	Fighter1Shots  int
+	Fighter1Hits   int
+	Fighter2Damage int
+	Fighter2Shots  int
+	Fighter2Hits   int
+}
+
+func (battleV6) TableName() string {
+	return "battle_models"
+}
+
+var battleV6Columns = []string{"fighter1_damage", "fighter1_shots", "fighter1_hits", "fighter2_damage", "fighter2_shots", "fighter2_hits"}
+
+// eventV6 : field ของ Event ที่ใช้คำนวณยอดรวม (หน้าตา JSON ณ ตอนนั้น)
+type eventV6 struct {
+	Type       string `json:"type"`
+	AttackerID string `json:"attacker_id"`
+	Damage     int    `json:"damage"`
+}
+
+// backfillBattleTotals : คำนวณยอดรวมจาก Events ของ record ที่มีอยู่แล้ว ทีละ 500 แถว
+func backfillBattleTotals(tx *gorm.DB) error {
+	var rows []battleV6
+	return tx.Select("id", "fighter1_id", "events").
+		Where("events IS NOT NULL AND events <> ''").
+		FindInBatches(&rows, 500, func(batch *gorm.DB, _ int) error {
+			for _, row := range rows {
+				var events []eventV6
+				if err := json.Unmarshal([]byte(row.Events), &events); err != nil {
+					return err
+				}
+				for _, e := range events {
+					if e.Type != "hit" && e.Type != "miss" {
+						continue
+					}
+					damage, shots, hits := &row.Fighter1Damage, &row.Fighter1Shots, &row.Fighter1Hits
+					if e.AttackerID != row.Fighter1ID {
+						damage, shots, hits = &row.Fighter2Damage, &row.Fighter2Shots, &row.Fighter2Hits
+					}
+					*shots++
+					if e.Type == "hit" {
+						*hits++
+						*damage += e.Damage
+					}
+				}
+				err := tx.Model(&battleV6{}).Where("id = ?", row.ID).Updates(map[string]any{
+					"fighter1_damage": row.Fighter1Damage,
+					"fighter1_shots":  row.Fighter1Shots,
+					"fighter1_hits":   row.Fighter1Hits,
+					"fighter2_damage": row.Fighter2Damage,
+					"fighter2_shots":  row.Fighter2Shots,
+					"fighter2_hits":   row.Fighter2Hits,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+type battleV7 struct {
+	Fighter1ID string    `gorm:"size:191;index:idx_battles_pair,priority:1"`
+	Fighter2ID string    `gorm:"size:191;index:idx_battles_pair,priority:2;index"`
+	WinnerID   string    `gorm:"size:191;index"`
+	Turns      int       `gorm:"index"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+func (battleV7) TableName() string {
+	return "battle_models"
+}
+
+var battleV7Indexes = []string{
+	"idx_battles_pair",
+	"idx_battle_models_fighter2_id",
+	"idx_battle_models_winner_id",
+	"idx_battle_models_turns",
+	"idx_battle_models_created_at",
+}
+
+type snapshotV8 struct {
+	Health   int
+	Damage   int
+	Speed    int
+	Accuracy float64
+	Version  int
+}
+
+type battleV8 struct {
+	Fighter1Stats snapshotV8 `gorm:"embedded;embeddedPrefix:fighter1_stat_"`
+	Fighter2Stats snapshotV8 `gorm:"embedded;embeddedPrefix:fighter2_stat_"`
+}
+
+func (battleV8) TableName() string {
+	return "battle_models"
+}
+
+var battleV8Columns = []string{
+	"fighter1_stat_health", "fighter1_stat_damage", "fighter1_stat_speed", "fighter1_stat_accuracy", "fighter1_stat_version",
+	"fighter2_stat_health", "fighter2_stat_damage", "fighter2_stat_speed", "fighter2_stat_accuracy", "fighter2_stat_version",
+}
+
+type battleV9 struct {
+	ID             uint `gorm:"primaryKey"`
+	Fighter1ID     string
+	Fighter1Name   string
+	Fighter2ID     string
+	Fighter2Name   string
+	WinnerID       string
+	Winner         string
+	Turns          int
+	Logs           string
+	Fighter1Damage int
+	Fighter1Shots  int
+	Fighter1Hits   int
+	Fighter2Damage int
+	Fighter2Shots  int
+	Fighter2Hits   int
+}
+
+func (battleV9) TableName() string {
+	return "battle_models"
+}
+
+// ข้อความ log ของ engine รุ่นแรก (ก่อนมี Events)
+var (
+	legacyStartLog = regexp.MustCompile(`^🔥 Match Start: (.+) \(HP:-?\d+\) VS (.+) \(HP:-?\d+\)$`)
+	legacyTurnLog  = regexp.MustCompile(`^--- Turn (\d+) ---$`)
+	legacyHitLog   = regexp.MustCompile(` for (-?\d+) \(HP left: -?\d+\)$`)
+)
+
+// backfillLegacyBattles : เติม column ที่ record รุ่นแรกไม่มี จาก Logs ทีละ 500 แถว
+// ชื่อสองฝั่งซ้ำกันจะแยกไม่ออกว่าใครเป็นใคร ได้แค่ชื่อกับจำนวนเทิร์น (winner_id ยังว่าง)
+func backfillLegacyBattles(tx *gorm.DB) error {
+	var rows []battleV9
+	return tx.Where("(events IS NULL OR events = '') AND (fighter1_name IS NULL OR fighter1_name = '') AND logs <> ''").
+		FindInBatches(&rows, 500, func(batch *gorm.DB, _ int) error {
+			for i := range rows {
+				row := &rows[i]
+				if !parseLegacyLogs(row) {
+					continue
+				}
+				err := tx.Model(&battleV9{}).Where("id = ?", row.ID).Updates(map[string]any{
+					"fighter1_name":   row.Fighter1Name,
+					"fighter2_name":   row.Fighter2Name,
+					"winner_id":       row.WinnerID,
+					"turns":           row.Turns,
+					"fighter1_damage": row.Fighter1Damage,
+					"fighter1_shots":  row.Fighter1Shots,
+					"fighter1_hits":   row.Fighter1Hits,
+					"fighter2_damage": row.Fighter2Damage,
+					"fighter2_shots":  row.Fighter2Shots,
+					"fighter2_hits":   row.Fighter2Hits,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// parseLegacyLogs : อ่าน Logs แล้วเติมค่าลง row (false = ไม่ใช่รูปแบบที่รู้จัก)
+func parseLegacyLogs(row *battleV9) bool {
+	lines := strings.Split(row.Logs, "\n")
+	start := legacyStartLog.FindStringSubmatch(lines[0])
+	if start == nil {
+		return false
+	}
+	row.Fighter1Name, row.Fighter2Name = start[1], start[2]
+
+	for _, line := range lines[1:] {
+		if m := legacyTurnLog.FindStringSubmatch(line); m != nil {
+			row.Turns, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	// ชื่อซ้ำกัน = บอกไม่ได้ว่าใครยิง / ใครชนะ
+	if row.Fighter1Name == row.Fighter2Name {
+		return true
+	}
+	switch row.Winner {
+	case row.Fighter1Name:
+		row.WinnerID = row.Fighter1ID
+	case row.Fighter2Name:
+		row.WinnerID = row.Fighter2ID
+	}
+
+	for _, line := range lines[1:] {
+		var damage, shots, hits *int
+		switch {
+		case strings.HasPrefix(line, "💥 "+row.Fighter1Name+" hits "), line == "💨 "+row.Fighter1Name+" missed!":
+			damage, shots, hits = &row.Fighter1Damage, &row.Fighter1Shots, &row.Fighter1Hits
+		case strings.HasPrefix(line, "💥 "+row.Fighter2Name+" hits "), line == "💨 "+row.Fighter2Name+" missed!":
+			damage, shots, hits = &row.Fighter2Damage, &row.Fighter2Shots, &row.Fighter2Hits
+		default:
+			continue
+		}
+		*shots++
+		if m := legacyHitLog.FindStringSubmatch(line); m != nil && strings.HasPrefix(line, "💥 ") {
+			dmg, _ := strconv.Atoi(m[1])
+			*hits++
+			*damage += dmg
+		}
+	}
+	return true
+}
+
+type battleV10 struct {
+	ID              uint `gorm:"primaryKey"`
+	TournamentID    *uint
+	TournamentRound int `gorm:"not null;default:0"`
+	SupersededAt    *time.Time
+	Fighter1ID      string
+	Fighter2ID      string
+}
+
+func (battleV10) TableName() string {
+	return "battle_models"
+}
+
+type tournamentV10 struct {
+	Version int `gorm:"not null;default:1"`
+}
+
+func (tournamentV10) TableName() string {
+	return "tournaments"
+}
+
+type tournamentMatchV10 struct {
+	TournamentID uint
+	Round        int
+	Fighter1ID   string
+	Fighter2ID   string
+	BattleID     uint
+	Replays      int `gorm:"not null;default:0"`
+}
+
+func (tournamentMatchV10) TableName() string {
+	return "tournament_matches"
+}
+
+// backfillTournamentRounds : ใส่รอบให้ battle ของทัวร์นาเมนต์เดิม จาก battle ที่ตัดสินแต่ละคู่
+// battle ของคู่เดียวกันที่ id ไม่เกินตัวตัดสิน (ดวลใหม่เพราะเสมอ) อยู่รอบเดียวกัน
+func backfillTournamentRounds(tx *gorm.DB) error {
+	var matches []tournamentMatchV10
+	if err := tx.Where("battle_id > 0").Order("battle_id").Find(&matches).Error; err != nil {
+		return err
+	}
+	for _, m := range matches {
+		err := tx.Model(&battleV10{}).
+			Where("tournament_id = ? AND tournament_round = 0 AND id <= ?", m.TournamentID, m.BattleID).
+			Where("(fighter1_id = ? AND fighter2_id = ?) OR (fighter1_id = ? AND fighter2_id = ?)",
+				m.Fighter1ID, m.Fighter2ID, m.Fighter2ID, m.Fighter1ID).
+			Update("tournament_round", m.Round).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type battleV11 struct {
+	MaxTurns int `gorm:"not null;default:0"`
+}
+
+func (battleV11) TableName() string {
+	return "battle_models"
+}
@@ -3,85 +3,318 @@ package repository
 import (
 	"api/services/arena/internal/core/domain"
 	"api/services/arena/internal/core/ports"
+	"context"
+	"encoding/json"
+	"errors"
 	"strings"
 	"time"
+
 	"gorm.io/gorm"
 )
 
 type battleModel struct {
-	ID         uint `gorm:"primaryKey"`
-	Fighter1ID string
-	Fighter2ID string
-	Winner     string
-	Logs       string `gorm:"type:text"`
-	CreatedAt  time.Time
+	ID              uint       `gorm:"primaryKey"`
+	SeriesID        *uint      `gorm:"index"` // NULL = ไม่ได้อยู่ในซีรีส์
+	TournamentID    *uint      `gorm:"index"` // NULL = ไม่ได้อยู่ในทัวร์นาเมนต์
+	TournamentRound int        `gorm:"not null;default:0"`
+	SupersededAt    *time.Time // ไม่ NULL = ถูกแทนด้วยการเล่นรอบใหม่ (ไม่นับในประวัติ / สถิติ)
+	// idx_battles_pair ใช้ค้นคู่ดวล / fighter1_id, index เดี่ยวของ fighter2_id ใช้กับฝั่ง OR
+	Fighter1ID   string `gorm:"size:191;index:idx_battles_pair,priority:1"`
+	Fighter1Name string
+	Fighter2ID   string `gorm:"size:191;index:idx_battles_pair,priority:2;index"`
+	Fighter2Name string
+	Result       string `gorm:"size:16;default:win"` // win / draw (record เก่าไม่มีเสมอ)
+	WinnerID     string `gorm:"size:191;index"`
+	Winner       string
+	Turns        int `gorm:"index"`              // sort longest / shortest
+	MaxTurns     int `gorm:"not null;default:0"` // Rules ตอนดวล (0 = record เก่าก่อนเริ่มเก็บ)
+	// ยอดรวมต่อฝ่าย เก็บแยกไว้ให้ query สถิติด้วย SUM ได้โดยไม่ต้องแกะ Events
+	Fighter1Damage int
+	Fighter1Shots  int
+	Fighter1Hits   int
+	Fighter2Damage int
+	Fighter2Shots  int
+	Fighter2Hits   int
+	// ค่าสถานะตอนลงดวล (ค่าเป็น 0 ทั้งหมด = record เก่าก่อนเริ่มเก็บ)
+	Fighter1Stats snapshotModel `gorm:"embedded;embeddedPrefix:fighter1_stat_"`
+	Fighter2Stats snapshotModel `gorm:"embedded;embeddedPrefix:fighter2_stat_"`
+	Events        string        `gorm:"type:text"` // JSON ของ []domain.BattleEvent
+	Logs          string        `gorm:"type:text"` // Legacy: record เก่าก่อนมี Events (ไม่เขียนเพิ่มแล้ว)
+	Seed          int64
+	CreatedAt     time.Time `gorm:"index"` // กรองช่วงวันที่
+}
+
+// snapshotModel : column ของ domain.FighterSnapshot (ฝังใน battles)
+type snapshotModel struct {
+	Health   int
+	Damage   int
+	Speed    int
+	Accuracy float64
+	Version  int
+}
+
+func (s snapshotModel) toDomain() *domain.FighterSnapshot {
+	if s == (snapshotModel{}) {
+		return nil
+	}
+	return &domain.FighterSnapshot{
+		Health:   s.Health,
+		Damage:   s.Damage,
+		Speed:    s.Speed,
+		Accuracy: s.Accuracy,
+		Version:  s.Version,
+	}
+}
+
+func snapshotFromDomain(s *domain.FighterSnapshot) snapshotModel {
+	if s == nil {
+		return snapshotModel{}
+	}
+	return snapshotModel{
+		Health:   s.Health,
+		Damage:   s.Damage,
+		Speed:    s.Speed,
+		Accuracy: s.Accuracy,
+		Version:  s.Version,
+	}
+}
+
+// แปลงจาก Model -> Domain (Logs render ใหม่จาก Events เสมอ)
+func (m *battleModel) toDomain() (domain.BattleResult, error) {
+	res := domain.BattleResult{
+		ID:              m.ID,
+		SeriesID:        derefUint(m.SeriesID),
+		TournamentID:    derefUint(m.TournamentID),
+		TournamentRound: m.TournamentRound,
+		SupersededAt:    m.SupersededAt,
+		Fighter1ID:      m.Fighter1ID,
+		Fighter1Name:    m.Fighter1Name,
+		Fighter2ID:      m.Fighter2ID,
+		Fighter2Name:    m.Fighter2Name,
+		Fighter1Stats:   m.Fighter1Stats.toDomain(),
+		Fighter2Stats:   m.Fighter2Stats.toDomain(),
+		Result:          domain.Result(m.Result),
+		WinnerID:        m.WinnerID,
+		Winner:          m.Winner,
+		Turns:           m.Turns,
+		MaxTurns:        m.MaxTurns,
+		Seed:            m.Seed,
+		CreatedAt:       m.CreatedAt,
+	}
+	if m.Events == "" {
+		// record เก่ามีแค่ Logs แบบข้อความ
+		res.Logs = strings.Split(m.Logs, "\n")
+		return res, nil
+	}
+	if err := json.Unmarshal([]byte(m.Events), &res.Events); err != nil {
+		return res, err
+	}
+	res.Logs = domain.RenderLogs(res.Events)
+	return res, nil
+}
+
+// แปลงจาก Domain -> Model (Events เก็บเป็น JSON)
+func fromDomain(res *domain.BattleResult) (*battleModel, error) {
+	events, err := json.Marshal(res.Events)
+	if err != nil {
+		return nil, err
+	}
+	t1, t2 := res.Totals()
+	m := &battleModel{
+		Fighter1ID:      res.Fighter1ID,
+		Fighter1Name:    res.Fighter1Name,
+		Fighter2ID:      res.Fighter2ID,
+		Fighter2Name:    res.Fighter2Name,
+		Result:          string(res.Result),
+		WinnerID:        res.WinnerID,
+		Winner:          res.Winner,
+		Turns:           res.Turns,
+		MaxTurns:        res.MaxTurns,
+		Fighter1Damage:  t1.DamageDealt,
+		Fighter1Shots:   t1.Shots,
+		Fighter1Hits:    t1.Hits,
+		Fighter2Damage:  t2.DamageDealt,
+		Fighter2Shots:   t2.Shots,
+		Fighter2Hits:    t2.Hits,
+		Fighter1Stats:   snapshotFromDomain(res.Fighter1Stats),
+		Fighter2Stats:   snapshotFromDomain(res.Fighter2Stats),
+		Events:          string(events),
+		Seed:            res.Seed,
+		TournamentRound: res.TournamentRound,
+		SupersededAt:    res.SupersededAt,
+	}
+	if res.SeriesID != 0 {
+		m.SeriesID = &res.SeriesID
+	}
+	if res.TournamentID != 0 {
+		m.TournamentID = &res.TournamentID
+	}
+	return m, nil
 }
 
 type mysqlRepo struct {
-	db *gorm.DB
+	db     *gorm.DB
+	reader *gorm.DB // ประวัติ / สถิติ อ่านจาก replica ได้ (ไม่มี replica = ตัวเดียวกับ db)
+	k      float64  // ค่า K ของ Elo ที่ใช้อัปเดตคะแนนตอนบันทึก battle
 }
 
-func NewMySQLRepository(db *gorm.DB) ports.BattleRepository {
-	db.AutoMigrate(&battleModel{})
-	return &mysqlRepo{db: db}
+// NewMySQLRepository : ตารางต้องถูกสร้างด้วย Migrations() มาก่อนแล้ว
+// FindByID ยังอ่านจาก db เพราะต้องเห็น battle ที่เพิ่งบันทึกทันที
+func NewMySQLRepository(db, reader *gorm.DB, k float64) ports.BattleRepository {
+	return &mysqlRepo{db: db, reader: reader, k: k}
 }
 
-func (r *mysqlRepo) Save(res *domain.BattleResult, f1, f2 string) error {
-	m := battleModel{
-		Fighter1ID: f1,
-		Fighter2ID: f2,
-		Winner:     res.Winner,
-		Logs:       strings.Join(res.Logs, "\n"),
+func (r *mysqlRepo) Save(ctx context.Context, res *domain.BattleResult) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createBattle(tx, r.k, res)
+	})
+}
+
+// createBattle : insert 1 battle แล้วอัปเดตคะแนนของทั้งสองฝ่าย (ต้องเรียกใน transaction)
+// คะแนนพัง = battle ก็ไม่ถูกบันทึก ladder จะได้ตรงกับประวัติเสมอ
+func createBattle(tx *gorm.DB, k float64, res *domain.BattleResult) error {
+	m, err := fromDomain(res)
+	if err != nil {
+		return err
+	}
+	if err := tx.Create(m).Error; err != nil {
+		return err
 	}
-	return r.db.Create(&m).Error
+
+	// เติมค่าที่ DB สร้างให้กลับไปที่ Domain Object
+	res.ID = m.ID
+	res.CreatedAt = m.CreatedAt
+	return applyRatings(tx, k, res)
 }
 
-func (r *mysqlRepo) GetAll() ([]domain.BattleResult, error) {
-	var models []battleModel
-	if err := r.db.Order("created_at desc").Find(&models).Error; err != nil {
+func (r *mysqlRepo) FindByID(ctx context.Context, id uint) (*domain.BattleResult, error) {
+	var m battleModel
+	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBattleNotFound
+		}
 		return nil, err
 	}
-	
-	var results []domain.BattleResult
-	for _, m := range models {
-		results = append(results, domain.BattleResult{
-			Winner: m.Winner,
-			Logs:   strings.Split(m.Logs, "\n"),
-		})
+	res, err := m.toDomain()
+	if err != nil {
+		return nil, err
 	}
-	return results, nil
+	return &res, nil
 }
 
-func (r *mysqlRepo) GetHistory(limit int, fighterID string) ([]domain.BattleResult, error) {
+func (r *mysqlRepo) GetHistory(ctx context.Context, filter domain.HistoryFilter, after *domain.HistoryCursor) ([]domain.BattleResult, error) {
 	var models []battleModel
-	
-	// เริ่มต้น Query
-	query := r.db.Order("created_at desc")
+	query := r.reader.WithContext(ctx).Limit(filter.Limit).Where("superseded_at IS NULL")
 
-	// 1. ถ้ามี limit ให้ใส่ limit (ถ้าเป็น 0 ให้ default สัก 50 กันบึ้ม)
-	if limit > 0 {
-		query = query.Limit(limit)
-	} else {
-		query = query.Limit(50) 
+	// 1. กรองตาม Cowboy (คู่ดวลต้องหาทั้งสองทิศ)
+	switch {
+	case filter.FighterID != "" && filter.OpponentID != "":
+		query = query.Where("(fighter1_id = ? AND fighter2_id = ?) OR (fighter1_id = ? AND fighter2_id = ?)",
+			filter.FighterID, filter.OpponentID, filter.OpponentID, filter.FighterID)
+	case filter.FighterID != "":
+		query = query.Where("fighter1_id = ? OR fighter2_id = ?", filter.FighterID, filter.FighterID)
+	}
+	if filter.WinnerID != "" {
+		query = query.Where("winner_id = ?", filter.WinnerID)
+	}
+	if filter.TournamentID != 0 {
+		query = query.Where("tournament_id = ?", filter.TournamentID)
+	}
+	if filter.SeriesID != 0 {
+		query = query.Where("series_id = ?", filter.SeriesID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
 	}
 
-	// 2. ถ้าระบุ fighterID ให้หาทั้งช่อง fighter1 หรือ fighter2
-	if fighterID != "" {
-		query = query.Where("fighter1_id = ? OR fighter2_id = ?", fighterID, fighterID)
+	// 2. เรียง + keyset (ID เพิ่มตามเวลาที่บันทึก ใช้แทน created_at ได้และไม่มีค่าซ้ำ)
+	switch filter.Sort {
+	case domain.SortOldest:
+		query = query.Order("id")
+		if after != nil {
+			query = query.Where("id > ?", after.ID)
+		}
+	case domain.SortLongest:
+		query = query.Order("turns desc").Order("id desc")
+		if after != nil {
+			query = query.Where("turns < ? OR (turns = ? AND id < ?)", after.Turns, after.Turns, after.ID)
+		}
+	case domain.SortShortest:
+		query = query.Order("turns").Order("id")
+		if after != nil {
+			query = query.Where("turns > ? OR (turns = ? AND id > ?)", after.Turns, after.Turns, after.ID)
+		}
+	default:
+		query = query.Order("id desc")
+		if after != nil {
+			query = query.Where("id < ?", after.ID)
+		}
 	}
 
-	// รัน Query
 	if err := query.Find(&models).Error; err != nil {
 		return nil, err
 	}
-	
-	// แปลงเป็น Domain Object (เหมือนเดิม)
+	return toDomainList(models)
+}
+
+func toDomainList(models []battleModel) ([]domain.BattleResult, error) {
 	var results []domain.BattleResult
 	for _, m := range models {
-		results = append(results, domain.BattleResult{
-			Winner: m.Winner,
-			Logs:   strings.Split(m.Logs, "\n"),
-		})
+		res, err := m.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
 	}
 	return results, nil
-}
\ No newline at end of file
+}
+
+// FighterStats : รวมสถิติด้วย aggregate query ครั้งเดียว (GROUP BY คู่ต่อสู้)
+func (r *mysqlRepo) FighterStats(ctx context.Context, cowboyID string) (*domain.FighterStats, error) {
+	// เลือก column ฝั่งตัวเอง / ฝั่งคู่ต่อสู้ ตามว่าอยู่ช่อง fighter1 หรือ fighter2
+	mine := func(col string) string {
+		return "CASE WHEN fighter1_id = @id THEN fighter1_" + col + " ELSE fighter2_" + col + " END"
+	}
+	theirs := func(col string) string {
+		return "CASE WHEN fighter1_id = @id THEN fighter2_" + col + " ELSE fighter1_" + col + " END"
+	}
+
+	cols := []string{
+		theirs("id") + " AS opponent_id",
+		"MAX(" + theirs("name") + ") AS opponent_name",
+		"COUNT(*) AS battles",
+		"SUM(CASE WHEN winner_id = @id THEN 1 ELSE 0 END) AS wins",
+		// นับแพ้ตรงๆ (ไม่ใช่ battles - wins - draws) record เก่าที่ยังไม่รู้ผู้ชนะจะได้ไม่กลายเป็นแพ้
+		"SUM(CASE WHEN result <> 'draw' AND winner_id <> '' AND winner_id <> @id THEN 1 ELSE 0 END) AS losses",
+		"SUM(CASE WHEN result = 'draw' THEN 1 ELSE 0 END) AS draws",
+		"SUM(turns) AS turns",
+		"SUM(" + mine("damage") + ") AS damage_dealt",
+		"SUM(" + theirs("damage") + ") AS damage_taken",
+		"SUM(" + mine("shots") + ") AS shots",
+		"SUM(" + mine("hits") + ") AS hits",
+	}
+	args := map[string]any{"id": cowboyID}
+
+	var rows []domain.HeadToHead
+	err := r.reader.WithContext(ctx).Model(&battleModel{}).
+		Select(strings.Join(cols, ", "), args).
+		Where("fighter1_id = @id OR fighter2_id = @id", args).
+		Where("superseded_at IS NULL").
+		Group("opponent_id").
+		Order("battles desc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return domain.SummarizeStats(cowboyID, rows), nil
+}
+
+func derefUint(p *uint) uint {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/domain/entity"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSaveKeepsRulesAndEvents(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewMySQLRepository(db, db, 32)
+	ctx := context.Background()
+
+	c1 := &entity.Cowboy{ID: "kid", Name: "Kid", Health: 100, Damage: 5, Speed: 10, Accuracy: 0.5}
+	c2 := &entity.Cowboy{ID: "doc", Name: "Doc", Health: 100, Damage: 5, Speed: 10, Accuracy: 0.5}
+	res := domain.SimulateFight(c1, c2, 2024, domain.Rules{MaxTurns: 4})
+	if err := repo.Save(ctx, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.FindByID(ctx, res.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MaxTurns != 4 || got.Seed != 2024 {
+		t.Fatalf("stored max_turns / seed = %d / %d, want 4 / 2024", got.MaxTurns, got.Seed)
+	}
+	if !reflect.DeepEqual(got.Events, res.Events) || !reflect.DeepEqual(got.Logs, res.Logs) {
+		t.Fatal("events / logs changed after a round trip through the database")
+	}
+}
+
+func TestGetHistoryKeysetMatchesMemory(t *testing.T) {
+	db := openTestDB(t)
+	repos := map[string]ports.BattleRepository{
+		"gorm":   NewMySQLRepository(db, db, 32),
+		"memory": NewMemoryRepository(NewMemoryStore(32)),
+	}
+	ctx := context.Background()
+	for _, repo := range repos {
+		for _, n := range []int{3, 5, 5, 1, 5, 2} {
+			if err := repo.Save(ctx, &domain.BattleResult{Fighter1ID: "kid", Fighter2ID: "doc", Result: domain.ResultDraw, Turns: n}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// cursor ชี้ที่ battle ตัวกลางที่เทิร์นซ้ำกับตัวอื่น (ID 3, 5 เทิร์น) ต้องได้ตัวถัดไปตรงกันทั้งสองแบบ
+	after := &domain.HistoryCursor{Turns: 5, ID: 3}
+	for _, sort := range []domain.HistorySort{domain.SortNewest, domain.SortOldest, domain.SortLongest, domain.SortShortest} {
+		t.Run(string(sort), func(t *testing.T) {
+			after.Sort = sort
+			ids := map[string][]uint{}
+			for name, repo := range repos {
+				battles, err := repo.GetHistory(ctx, domain.HistoryFilter{Sort: sort, Limit: 10}, after)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, b := range battles {
+					ids[name] = append(ids[name], b.ID)
+				}
+			}
+			if !slices.Equal(ids["gorm"], ids["memory"]) {
+				t.Fatalf("gorm = %v, memory = %v", ids["gorm"], ids["memory"])
+			}
+			if slices.Contains(ids["gorm"], after.ID) {
+				t.Fatalf("page after cursor repeats battle %d: %v", after.ID, ids["gorm"])
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DB Entity: คะแนนปัจจุบันของ Cowboy (1 แถวต่อ 1 ตัว)
+type ratingModel struct {
+	CowboyID  string `gorm:"primaryKey"`
+	Name      string
+	Rating    float64 `gorm:"index"`
+	Games     int
+	Wins      int
+	Losses    int
+	Draws     int
+	UpdatedAt time.Time
+}
+
+func (ratingModel) TableName() string {
+	return "ratings"
+}
+
+// DB Entity: ประวัติการเปลี่ยนคะแนน (เพิ่มอย่างเดียว ไม่แก้)
+type ratingHistoryModel struct {
+	ID         uint   `gorm:"primaryKey"`
+	CowboyID   string `gorm:"index"`
+	BattleID   uint
+	OpponentID string
+	Before     float64
+	After      float64
+	Delta      float64
+	CreatedAt  time.Time
+}
+
+func (ratingHistoryModel) TableName() string {
+	return "rating_history"
+}
+
+func (m *ratingModel) toDomain() domain.Rating {
+	return domain.Rating{
+		CowboyID:  m.CowboyID,
+		Name:      m.Name,
+		Rating:    m.Rating,
+		Games:     m.Games,
+		Wins:      m.Wins,
+		Losses:    m.Losses,
+		Draws:     m.Draws,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func ratingFromDomain(rt *domain.Rating) ratingModel {
+	return ratingModel{
+		CowboyID: rt.CowboyID,
+		Name:     rt.Name,
+		Rating:   rt.Rating,
+		Games:    rt.Games,
+		Wins:     rt.Wins,
+		Losses:   rt.Losses,
+		Draws:    rt.Draws,
+	}
+}
+
+type ratingRepo struct {
+	db *gorm.DB
+}
+
+func NewRatingRepository(db *gorm.DB) ports.RatingRepository {
+	return &ratingRepo{db: db}
+}
+
+func (r *ratingRepo) FindRating(ctx context.Context, cowboyID string) (*domain.Rating, error) {
+	var m ratingModel
+	if err := r.db.WithContext(ctx).First(&m, "cowboy_id = ?", cowboyID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rating := m.toDomain()
+	return &rating, nil
+}
+
+// applyRatings : อัปเดตคะแนนทั้งสองฝ่ายจาก battle ที่เพิ่ง insert (ต้องเรียกใน transaction เดียวกับ battle)
+func applyRatings(tx *gorm.DB, k float64, res *domain.BattleResult) error {
+	ratings, err := lockRatings(tx, res)
+	if err != nil {
+		return err
+	}
+	r1, r2 := ratings[res.Fighter1ID], ratings[res.Fighter2ID]
+	c1, c2 := domain.ApplyElo(r1, r2, res, k)
+	return saveRatings(tx, []*domain.Rating{r1, r2}, []domain.RatingChange{c1, c2})
+}
+
+// revertRatings : ย้อนคะแนนที่ battle นี้เคยให้ ตามประวัติที่บันทึกไว้ (battle ที่ไม่เคยนับคะแนนไม่มีประวัติ = ข้าม)
+func revertRatings(tx *gorm.DB, res *domain.BattleResult) error {
+	var history []ratingHistoryModel
+	if err := tx.Where("battle_id = ?", res.ID).Order("id").Find(&history).Error; err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	ratings, err := lockRatings(tx, res)
+	if err != nil {
+		return err
+	}
+	var changed []*domain.Rating
+	var reverts []domain.RatingChange
+	for _, h := range history {
+		rt := ratings[h.CowboyID]
+		if rt == nil {
+			return fmt.Errorf("rating history of battle %d belongs to cowboy %q outside the battle", res.ID, h.CowboyID)
+		}
+		reverts = append(reverts, domain.RevertElo(rt, domain.RatingChange{BattleID: h.BattleID, OpponentID: h.OpponentID, Delta: h.Delta}, res))
+		changed = append(changed, rt)
+	}
+	return saveRatings(tx, changed, reverts)
+}
+
+// lockRatings : อ่านคะแนนล่าสุดของทั้งสองฝ่ายพร้อมล็อกแถวด้วย SELECT ... FOR UPDATE (คนที่ยังไม่มีจะได้แถวเริ่มต้น)
+// หลาย instance บันทึกพร้อมกันก็ไม่ทับกัน
+func lockRatings(tx *gorm.DB, res *domain.BattleResult) (map[string]*domain.Rating, error) {
+	ids := []string{res.Fighter1ID, res.Fighter2ID}
+	names := map[string]string{res.Fighter1ID: res.Fighter1Name, res.Fighter2ID: res.Fighter2Name}
+	// ล็อกตามลำดับ cowboy_id เสมอ กัน deadlock ระหว่าง battle ที่มีคนเดียวกัน
+	sort.Strings(ids)
+
+	// 1. สร้างแถวให้คนที่ยังไม่มีคะแนน (มีแล้วข้าม) จะได้ล็อกได้ครบทั้งคู่
+	fresh := make([]ratingModel, 0, len(ids))
+	for _, id := range ids {
+		fresh = append(fresh, ratingFromDomain(domain.NewRating(id, names[id])))
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&fresh).Error; err != nil {
+		return nil, err
+	}
+
+	// 2. อ่านคะแนนล่าสุดพร้อมล็อก
+	var rows []ratingModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("cowboy_id IN ?", ids).Order("cowboy_id").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ratings := make(map[string]*domain.Rating, len(rows))
+	for _, m := range rows {
+		rt := m.toDomain()
+		ratings[rt.CowboyID] = &rt
+	}
+	if ratings[res.Fighter1ID] == nil || ratings[res.Fighter2ID] == nil {
+		return nil, fmt.Errorf("ratings of battle %d are missing after insert", res.ID)
+	}
+	return ratings, nil
+}
+
+// saveRatings : เขียนคะแนนใหม่ และเพิ่มประวัติ
+func saveRatings(tx *gorm.DB, ratings []*domain.Rating, changes []domain.RatingChange) error {
+	for _, rt := range ratings {
+		m := ratingFromDomain(rt)
+		if err := tx.Save(&m).Error; err != nil {
+			return err
+		}
+		rt.UpdatedAt = m.UpdatedAt
+	}
+
+	for _, c := range changes {
+		m := ratingHistoryModel{
+			CowboyID:   c.CowboyID,
+			BattleID:   c.BattleID,
+			OpponentID: c.OpponentID,
+			Before:     c.Before,
+			After:      c.After,
+			Delta:      c.Delta,
+		}
+		if err := tx.Create(&m).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ratingRepo) Leaderboard(ctx context.Context, filter domain.LeaderboardFilter) ([]domain.Rating, error) {
+	var models []ratingModel
+
+	query := r.db.WithContext(ctx).Order("rating desc").Order("cowboy_id").Limit(filter.Limit).Offset(filter.Offset)
+	if filter.MinGames > 0 {
+		query = query.Where("games >= ?", filter.MinGames)
+	}
+	if filter.NameContains != "" {
+		query = query.Where("name LIKE ?", "%"+filter.NameContains+"%")
+	}
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	ratings := make([]domain.Rating, 0, len(models))
+	for i, m := range models {
+		rt := m.toDomain()
+		rt.Rank = filter.Offset + i + 1
+		ratings = append(ratings, rt)
+	}
+	return ratings, nil
+}
+
+func (r *ratingRepo) History(ctx context.Context, cowboyID string, limit int) ([]domain.RatingChange, error) {
+	var models []ratingHistoryModel
+	err := r.db.WithContext(ctx).Where("cowboy_id = ?", cowboyID).Order("id desc").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]domain.RatingChange, 0, len(models))
+	for _, m := range models {
+		changes = append(changes, domain.RatingChange{
+			CowboyID:   m.CowboyID,
+			BattleID:   m.BattleID,
+			OpponentID: m.OpponentID,
+			Before:     m.Before,
+			After:      m.After,
+			Delta:      m.Delta,
+			CreatedAt:  m.CreatedAt,
+		})
+	}
+	return changes, nil
+}
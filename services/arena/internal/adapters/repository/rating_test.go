@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"api/pkg/database"
+	"api/services/arena/internal/core/domain"
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB : SQLite ไฟล์ชั่วคราวที่รัน Migrations ครบแล้ว
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	ctx := context.Background()
+	db, err := database.Open(ctx, database.Options{DSN: "sqlite://" + filepath.Join(t.TempDir(), "arena.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := database.NewMigrator(db.Primary(), "arena", Migrations())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return db.Primary()
+}
+
+func testBattle(winner string) *domain.BattleResult {
+	res := &domain.BattleResult{
+		Fighter1ID:   "kid",
+		Fighter1Name: "Kid",
+		Fighter2ID:   "doc",
+		Fighter2Name: "Doc",
+		Result:       domain.ResultDraw,
+		Turns:        3,
+	}
+	if winner != "" {
+		res.Result = domain.ResultWin
+		res.WinnerID = winner
+	}
+	return res
+}
+
+func TestSaveUpdatesRatingsInSameTransaction(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewMySQLRepository(db, db, 32)
+	ratings := NewRatingRepository(db)
+	ctx := context.Background()
+
+	if err := repo.Save(ctx, testBattle("kid")); err != nil {
+		t.Fatal(err)
+	}
+
+	kid, err := ratings.FindRating(ctx, "kid")
+	if err != nil || kid == nil {
+		t.Fatalf("FindRating(kid) = %v, %v", kid, err)
+	}
+	doc, _ := ratings.FindRating(ctx, "doc")
+	if kid.Rating != 1516 || doc.Rating != 1484 || kid.Wins != 1 || doc.Losses != 1 {
+		t.Fatalf("ratings = %+v / %+v", kid, doc)
+	}
+	history, _ := ratings.History(ctx, "kid", 10)
+	if len(history) != 1 || history[0].BattleID == 0 {
+		t.Fatalf("history = %+v", history)
+	}
+}
+
+func TestSaveRollsBackBattleWhenRatingFails(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewMySQLRepository(db, db, 32)
+	ctx := context.Background()
+
+	// ทำให้การเขียนประวัติคะแนนพัง
+	if err := db.Migrator().DropTable("rating_history"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, testBattle("kid")); err == nil {
+		t.Fatal("Save succeeded although the rating update failed")
+	}
+
+	var battles, ratings int64
+	db.Model(&battleModel{}).Count(&battles)
+	db.Model(&ratingModel{}).Count(&ratings)
+	if battles != 0 || ratings != 0 {
+		t.Fatalf("left %d battles and %d ratings behind, want none", battles, ratings)
+	}
+}
+
+func TestConcurrentSavesDoNotLoseRatingUpdates(t *testing.T) {
+	db := openTestDB(t)
+	// สอง repository แทน arena สอง instance ที่ใช้ DB เดียวกัน
+	repos := []interface {
+		Save(context.Context, *domain.BattleResult) error
+	}{NewMySQLRepository(db, db, 32), NewMySQLRepository(db, db, 32)}
+	ctx := context.Background()
+
+	const perRepo = 10
+	var wg sync.WaitGroup
+	for _, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perRepo; i++ {
+				if err := repo.Save(ctx, testBattle("")); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	kid, _ := NewRatingRepository(db).FindRating(ctx, "kid")
+	if want := len(repos) * perRepo; kid == nil || kid.Games != want || kid.Draws != want {
+		t.Fatalf("kid = %+v, want %d games", kid, want)
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"api/services/arena/internal/core/domain"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DB Entity ของซีรีส์ (เกมย่อยอยู่ใน battleModel ผ่าน series_id)
+type seriesModel struct {
+	ID           uint `gorm:"primaryKey"`
+	Fighter1ID   string
+	Fighter1Name string
+	Fighter2ID   string
+	Fighter2Name string
+	BestOf       int
+	Fighter1Wins int
+	Fighter2Wins int
+	Draws        int
+	Result       string `gorm:"size:16"`
+	WinnerID     string
+	Winner       string
+	Seed         int64
+	CreatedAt    time.Time
+}
+
+func (seriesModel) TableName() string {
+	return "series"
+}
+
+func (m *seriesModel) toDomain() *domain.Series {
+	return &domain.Series{
+		ID:           m.ID,
+		Fighter1ID:   m.Fighter1ID,
+		Fighter1Name: m.Fighter1Name,
+		Fighter2ID:   m.Fighter2ID,
+		Fighter2Name: m.Fighter2Name,
+		BestOf:       m.BestOf,
+		Fighter1Wins: m.Fighter1Wins,
+		Fighter2Wins: m.Fighter2Wins,
+		Draws:        m.Draws,
+		Result:       domain.Result(m.Result),
+		WinnerID:     m.WinnerID,
+		Winner:       m.Winner,
+		Seed:         m.Seed,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// SaveSeries : บันทึกซีรีส์และทุกเกม (พร้อมคะแนน) ใน transaction เดียว (ไม่มีซีรีส์ครึ่งๆ กลางๆ)
+func (r *mysqlRepo) SaveSeries(ctx context.Context, s *domain.Series) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		m := seriesModel{
+			Fighter1ID:   s.Fighter1ID,
+			Fighter1Name: s.Fighter1Name,
+			Fighter2ID:   s.Fighter2ID,
+			Fighter2Name: s.Fighter2Name,
+			BestOf:       s.BestOf,
+			Fighter1Wins: s.Fighter1Wins,
+			Fighter2Wins: s.Fighter2Wins,
+			Draws:        s.Draws,
+			Result:       string(s.Result),
+			WinnerID:     s.WinnerID,
+			Winner:       s.Winner,
+			Seed:         s.Seed,
+		}
+		if err := tx.Create(&m).Error; err != nil {
+			return err
+		}
+
+		for i := range s.Games {
+			s.Games[i].SeriesID = m.ID
+			if err := createBattle(tx, r.k, &s.Games[i]); err != nil {
+				return err
+			}
+		}
+
+		s.ID = m.ID
+		s.CreatedAt = m.CreatedAt
+		return nil
+	})
+}
+
+func (r *mysqlRepo) FindSeries(ctx context.Context, id uint) (*domain.Series, error) {
+	var m seriesModel
+	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	var games []battleModel
+	if err := r.db.WithContext(ctx).Where("series_id = ?", id).Order("id").Find(&games).Error; err != nil {
+		return nil, err
+	}
+
+	s := m.toDomain()
+	results, err := toDomainList(games)
+	if err != nil {
+		return nil, err
+	}
+	s.Games = results
+	return s, nil
+}
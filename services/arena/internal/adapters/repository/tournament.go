@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DB Entity ของทัวร์นาเมนต์ (Participants เก็บเป็น JSON เพราะไม่เปลี่ยนหลังสร้าง)
+type tournamentModel struct {
+	ID           uint `gorm:"primaryKey"`
+	Name         string
+	Format       string `gorm:"size:32"`
+	Seeding      string `gorm:"size:32"`
+	Status       string `gorm:"size:16"`
+	Seed         int64
+	CurrentRound int
+	TotalRounds  int
+	WinnerID     string
+	Winner       string
+	Participants string `gorm:"type:text"`
+	Version      int    `gorm:"not null;default:1"` // optimistic lock ของ SaveRound
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (tournamentModel) TableName() string {
+	return "tournaments"
+}
+
+// DB Entity ของแต่ละคู่ในสาย
+type tournamentMatchModel struct {
+	ID           uint `gorm:"primaryKey"`
+	TournamentID uint `gorm:"index"`
+	Round        int
+	Slot         int
+	Fighter1ID   string
+	Fighter2ID   string
+	WinnerID     string
+	BattleID     uint
+	Status       string `gorm:"size:16"`
+	Replays      int    `gorm:"not null;default:0"`
+}
+
+func (tournamentMatchModel) TableName() string {
+	return "tournament_matches"
+}
+
+type tournamentRepo struct {
+	db *gorm.DB
+	k  float64 // ค่า K ของ Elo (battle ในสายก็นับคะแนน)
+}
+
+func NewTournamentRepository(db *gorm.DB, k float64) ports.TournamentRepository {
+	return &tournamentRepo{db: db, k: k}
+}
+
+// แปลงจาก Domain -> Model
+func tournamentFromDomain(t *domain.Tournament) (*tournamentModel, error) {
+	participants, err := json.Marshal(t.Participants)
+	if err != nil {
+		return nil, err
+	}
+	return &tournamentModel{
+		ID:           t.ID,
+		Name:         t.Name,
+		Format:       string(t.Format),
+		Seeding:      string(t.Seeding),
+		Status:       string(t.Status),
+		Seed:         t.Seed,
+		CurrentRound: t.CurrentRound,
+		TotalRounds:  t.TotalRounds,
+		WinnerID:     t.WinnerID,
+		Winner:       t.Winner,
+		Participants: string(participants),
+		Version:      t.Version,
+		CreatedAt:    t.CreatedAt,
+	}, nil
+}
+
+func matchFromDomain(tournamentID uint, m *domain.TournamentMatch) *tournamentMatchModel {
+	return &tournamentMatchModel{
+		ID:           m.ID,
+		TournamentID: tournamentID,
+		Round:        m.Round,
+		Slot:         m.Slot,
+		Fighter1ID:   m.Fighter1ID,
+		Fighter2ID:   m.Fighter2ID,
+		WinnerID:     m.WinnerID,
+		BattleID:     m.BattleID,
+		Status:       string(m.Status),
+		Replays:      m.Replays,
+	}
+}
+
+// saveMatches : upsert ทุกคู่ แล้วเติม ID กลับเข้า Domain
+func saveMatches(tx *gorm.DB, t *domain.Tournament) error {
+	for i := range t.Matches {
+		mm := matchFromDomain(t.ID, &t.Matches[i])
+		if err := tx.Save(mm).Error; err != nil {
+			return err
+		}
+		t.Matches[i].ID = mm.ID
+	}
+	return nil
+}
+
+func (r *tournamentRepo) Create(ctx context.Context, t *domain.Tournament) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t.Version = 1
+		m, err := tournamentFromDomain(t)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+		t.ID = m.ID
+		t.CreatedAt = m.CreatedAt
+		return saveMatches(tx, t)
+	})
+}
+
+func (r *tournamentRepo) FindByID(ctx context.Context, id uint) (*domain.Tournament, error) {
+	var m tournamentModel
+	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTournamentNotFound
+		}
+		return nil, err
+	}
+
+	var matches []tournamentMatchModel
+	if err := r.db.WithContext(ctx).Where("tournament_id = ?", id).Order("round, slot").Find(&matches).Error; err != nil {
+		return nil, err
+	}
+
+	t := &domain.Tournament{
+		ID:           m.ID,
+		Name:         m.Name,
+		Format:       domain.TournamentFormat(m.Format),
+		Seeding:      domain.Seeding(m.Seeding),
+		Status:       domain.TournamentStatus(m.Status),
+		Seed:         m.Seed,
+		CurrentRound: m.CurrentRound,
+		TotalRounds:  m.TotalRounds,
+		WinnerID:     m.WinnerID,
+		Winner:       m.Winner,
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(m.Participants), &t.Participants); err != nil {
+		return nil, err
+	}
+	for _, mm := range matches {
+		t.Matches = append(t.Matches, domain.TournamentMatch{
+			ID:         mm.ID,
+			Round:      mm.Round,
+			Slot:       mm.Slot,
+			Fighter1ID: mm.Fighter1ID,
+			Fighter2ID: mm.Fighter2ID,
+			WinnerID:   mm.WinnerID,
+			BattleID:   mm.BattleID,
+			Status:     domain.MatchStatus(mm.Status),
+			Replays:    mm.Replays,
+		})
+	}
+	return t, nil
+}
+
+func (r *tournamentRepo) SaveRound(ctx context.Context, t *domain.Tournament, battles []domain.RoundBattle, replayFrom int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. อัปเดตสายก่อน ถ้า version ไม่ตรง = มี request อื่นบันทึกไปแล้ว ยกเลิกทั้งหมด
+		m, err := tournamentFromDomain(t)
+		if err != nil {
+			return err
+		}
+		m.Version = t.Version + 1
+		result := tx.Model(&tournamentModel{}).
+			Where("id = ? AND version = ?", t.ID, t.Version).
+			Select("*").Omit("id", "created_at").
+			Updates(m)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: tournament %d was changed by another request, reload and try again", domain.ErrConflict, t.ID)
+		}
+
+		// 2. เล่นรอบใหม่: battle เดิมตั้งแต่รอบนั้นไม่นับแล้ว
+		if replayFrom > 0 {
+			if err := supersedeBattles(tx, t.ID, replayFrom); err != nil {
+				return err
+			}
+		}
+
+		// 3. บันทึก battle ตามลำดับ (คู่ที่ดวลใหม่เพราะเสมอ battle สุดท้ายคือตัวตัดสิน)
+		for i := range battles {
+			rb := &battles[i]
+			rb.Battle.TournamentID = t.ID
+			if err := createBattle(tx, r.k, &rb.Battle); err != nil {
+				return err
+			}
+			rb.Match.BattleID = rb.Battle.ID
+		}
+
+		// 4. บันทึกทุกคู่ในสาย
+		if err := saveMatches(tx, t); err != nil {
+			return err
+		}
+		t.Version = m.Version
+		return nil
+	})
+}
+
+// supersedeBattles : ทำเครื่องหมาย battle ของทัวร์นาเมนต์ตั้งแต่รอบ fromRound ว่าถูกแทนที่ แล้วย้อนคะแนนที่เคยให้
+// ย้อนจาก battle ล่าสุดไปเก่าสุด คะแนนจะกลับไปเท่ากับก่อนเล่นรอบนั้น (ถ้าระหว่างนั้นไม่มีการดวลอื่น)
+func supersedeBattles(tx *gorm.DB, tournamentID uint, fromRound int) error {
+	var models []battleModel
+	err := tx.Where("tournament_id = ? AND tournament_round >= ? AND superseded_at IS NULL", tournamentID, fromRound).
+		Order("id desc").Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	ids := make([]uint, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+	if err := tx.Model(&battleModel{}).Where("id IN ?", ids).Update("superseded_at", now).Error; err != nil {
+		return err
+	}
+
+	for _, m := range models {
+		res, err := m.toDomain()
+		if err != nil {
+			return err
+		}
+		if err := revertRatings(tx, &res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
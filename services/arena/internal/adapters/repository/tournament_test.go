@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/domain/entity"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"errors"
+	"testing"
+)
+
+type testStores struct {
+	tournaments ports.TournamentRepository
+	battles     ports.BattleRepository
+	ratings     ports.RatingRepository
+}
+
+// storeVariants : repository ทุกแบบต้องทำงานเหมือนกัน
+func storeVariants(t *testing.T) map[string]func() testStores {
+	return map[string]func() testStores{
+		"gorm": func() testStores {
+			db := openTestDB(t)
+			return testStores{NewTournamentRepository(db, 32), NewMySQLRepository(db, db, 32), NewRatingRepository(db)}
+		},
+		"memory": func() testStores {
+			store := NewMemoryStore(32)
+			return testStores{NewMemoryTournamentRepository(store), NewMemoryRepository(store), NewMemoryRatingRepository(store)}
+		},
+	}
+}
+
+func newTestTournament(t *testing.T, repo ports.TournamentRepository) (*domain.Tournament, map[string]*entity.Cowboy) {
+	t.Helper()
+	cowboys := map[string]*entity.Cowboy{}
+	var list []*entity.Cowboy
+	for _, id := range []string{"kid", "doc", "ace", "joe"} {
+		c := &entity.Cowboy{ID: id, Name: id, Health: 30, Damage: 10, Speed: 10, Accuracy: 0.6}
+		cowboys[id] = c
+		list = append(list, c)
+	}
+	tour, err := domain.NewTournament("cup", domain.FormatRoundRobin, domain.SeedingAsGiven, list, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(context.Background(), tour); err != nil {
+		t.Fatal(err)
+	}
+	return tour, cowboys
+}
+
+// playAndSave : เล่นรอบถัดไปแล้วบันทึก (replayFrom > 0 = ล้างรอบนั้นก่อน)
+func playAndSave(t *testing.T, repo ports.TournamentRepository, tour *domain.Tournament, cowboys map[string]*entity.Cowboy, replayFrom int) {
+	t.Helper()
+	if replayFrom > 0 {
+		if err := tour.ResetRound(replayFrom); err != nil {
+			t.Fatal(err)
+		}
+	}
+	battles, err := tour.PlayRound(cowboys, domain.Rules{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveRound(context.Background(), tour, battles, replayFrom); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayRoundSupersedesOldBattles(t *testing.T) {
+	for name, open := range storeVariants(t) {
+		t.Run(name, func(t *testing.T) {
+			s := open()
+			ctx := context.Background()
+			tour, cowboys := newTestTournament(t, s.tournaments)
+
+			playAndSave(t, s.tournaments, tour, cowboys, 0)
+			afterRound1 := map[string]domain.Rating{}
+			for id := range cowboys {
+				rt, _ := s.ratings.FindRating(ctx, id)
+				afterRound1[id] = *rt
+			}
+			playAndSave(t, s.tournaments, tour, cowboys, 0)
+			playAndSave(t, s.tournaments, tour, cowboys, 0)
+
+			// เล่นรอบ 2 ใหม่: รอบ 2 และ 3 เดิมต้องถูกย้อนคะแนนจนกลับไปเท่าหลังรอบ 1
+			stored, err := s.tournaments.FindByID(ctx, tour.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := stored.ResetRound(2); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.tournaments.SaveRound(ctx, stored, nil, 2); err != nil {
+				t.Fatal(err)
+			}
+			for id, want := range afterRound1 {
+				got, _ := s.ratings.FindRating(ctx, id)
+				if diff := got.Rating - want.Rating; diff > 1e-9 || diff < -1e-9 || got.Games != want.Games ||
+					got.Wins != want.Wins || got.Losses != want.Losses || got.Draws != want.Draws {
+					t.Errorf("%s rating after replay = %+v, want %+v", id, *got, want)
+				}
+			}
+
+			page, err := s.battles.GetHistory(ctx, domain.HistoryFilter{TournamentID: tour.ID, Limit: 100}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, b := range page {
+				if b.TournamentRound != 1 || b.SupersededAt != nil {
+					t.Fatalf("history still lists battle %d of round %d (superseded %v)", b.ID, b.TournamentRound, b.SupersededAt)
+				}
+			}
+			if len(page) == 0 {
+				t.Fatal("round 1 battles disappeared from history")
+			}
+		})
+	}
+}
+
+func TestSaveRoundRejectsStaleVersion(t *testing.T) {
+	for name, open := range storeVariants(t) {
+		t.Run(name, func(t *testing.T) {
+			s := open()
+			ctx := context.Background()
+			tour, cowboys := newTestTournament(t, s.tournaments)
+
+			// สอง request โหลดสายเดียวกันพร้อมกัน
+			a, _ := s.tournaments.FindByID(ctx, tour.ID)
+			b, _ := s.tournaments.FindByID(ctx, tour.ID)
+			playAndSave(t, s.tournaments, a, cowboys, 0)
+			if a.Version != tour.Version+1 {
+				t.Fatalf("version after save = %d, want %d", a.Version, tour.Version+1)
+			}
+
+			battles, err := b.PlayRound(cowboys, domain.Rules{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.tournaments.SaveRound(ctx, b, battles, 0); !errors.Is(err, domain.ErrConflict) {
+				t.Fatalf("stale SaveRound = %v, want ErrConflict", err)
+			}
+
+			// ตัวที่แพ้ต้องไม่ทิ้ง battle หรือคะแนนไว้
+			page, _ := s.battles.GetHistory(ctx, domain.HistoryFilter{TournamentID: tour.ID, Limit: 100}, nil)
+			if len(page) != len(a.RoundMatches(1)) {
+				t.Fatalf("history has %d battles, want %d", len(page), len(a.RoundMatches(1)))
+			}
+			kid, _ := s.ratings.FindRating(ctx, "kid")
+			if kid.Games != 1 {
+				t.Fatalf("kid played %d rated games, want 1", kid.Games)
+			}
+		})
+	}
+}
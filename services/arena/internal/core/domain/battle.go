@@ -1,26 +1,150 @@
 package domain
 
 import (
-	"fmt"
 	"math/rand"
 	"time"
+
 	"api/services/arena/internal/core/domain/entity"
 )
 
+// Result : ผลการดวล (ชนะ / เสมอ)
+type Result string
+
+const (
+	ResultWin  Result = "win"
+	ResultDraw Result = "draw"
+)
+
+// DefaultMaxTurns : จำนวนเทิร์นสูงสุดถ้าไม่ได้ตั้งค่าไว้ (กันดวลไม่จบ เช่น Accuracy ต่ำมากทั้งคู่)
+const DefaultMaxTurns = 100
+
+// Rules : กติกาของการดวล
+type Rules struct {
+	MaxTurns int // ครบแล้วยังไม่มีใครตาย = เสมอ (<= 0 ใช้ DefaultMaxTurns)
+}
+
+func (r Rules) maxTurns() int {
+	if r.MaxTurns <= 0 {
+		return DefaultMaxTurns
+	}
+	return r.MaxTurns
+}
+
 // Value Object: เก็บผลลัพธ์ (ไม่มี logic)
+// Events คือข้อมูลจริง ส่วน Logs เป็นข้อความที่ render มาจาก Events
 type BattleResult struct {
-	Winner string
-	Logs   []string
+	ID              uint   `json:"id"`                         // Repository เป็นคนกำหนดตอน Save
+	SeriesID        uint   `json:"series_id,omitempty"`        // 0 = ไม่ได้อยู่ในซีรีส์
+	TournamentID    uint   `json:"tournament_id,omitempty"`    // 0 = ไม่ได้อยู่ในทัวร์นาเมนต์
+	TournamentRound int    `json:"tournament_round,omitempty"` // รอบในทัวร์นาเมนต์ที่ดวล
+	Fighter1ID      string `json:"fighter_1_id"`
+	Fighter1Name    string `json:"fighter_1_name"`
+	Fighter2ID      string `json:"fighter_2_id"`
+	Fighter2Name    string `json:"fighter_2_name"`
+	// ค่าสถานะตอนลงดวล (nil = record เก่าก่อนเริ่มเก็บ)
+	Fighter1Stats *FighterSnapshot `json:"fighter_1_stats,omitempty"`
+	Fighter2Stats *FighterSnapshot `json:"fighter_2_stats,omitempty"`
+	Result        Result           `json:"result"`
+	WinnerID      string           `json:"winner_id"` // ว่างถ้าเสมอ
+	Winner        string           `json:"winner"`    // ว่างถ้าเสมอ
+	Turns         int              `json:"turns"`
+	MaxTurns      int              `json:"max_turns,omitempty"` // กติกาที่ใช้ดวล (0 = record เก่าก่อนเริ่มเก็บ)
+	Seed          int64            `json:"seed"`                // seed ที่ใช้สุ่ม เอาไว้ replay การดวลซ้ำได้แบบเป๊ะๆ
+	Events        []BattleEvent    `json:"events"`
+	Logs          []string         `json:"logs"`
+	CreatedAt     time.Time        `json:"created_at"`
+	// เวลาที่ถูกแทนด้วยการเล่นรอบทัวร์นาเมนต์ใหม่ (ไม่นับในประวัติ สถิติ และคะแนนแล้ว)
+	SupersededAt *time.Time `json:"superseded_at,omitempty"`
+}
+
+// FighterSnapshot : ค่าสถานะของนักสู้ ณ ตอนเริ่มดวล (ก่อนเสียเลือด)
+// เก็บไว้กับผลการดวล ให้ประวัติยังถูกต้องแม้ Duelist จะปรับค่าทีหลัง
+type FighterSnapshot struct {
+	Health   int     `json:"health"`
+	Damage   int     `json:"damage"`
+	Speed    int     `json:"speed"`
+	Accuracy float64 `json:"accuracy"`
+	Version  int     `json:"version,omitempty"`
+}
+
+// SnapshotOf : ถ่ายค่าสถานะปัจจุบันของ Cowboy
+func SnapshotOf(c *entity.Cowboy) *FighterSnapshot {
+	return &FighterSnapshot{
+		Health:   c.Health,
+		Damage:   c.Damage,
+		Speed:    c.Speed,
+		Accuracy: c.Accuracy,
+		Version:  c.Version,
+	}
+}
+
+// IsDraw : เสมอหรือไม่
+func (r *BattleResult) IsDraw() bool {
+	return r.Result == ResultDraw
+}
+
+// NewSeed : สุ่ม seed ใหม่สำหรับการดวลแต่ละครั้ง
+func NewSeed() int64 {
+	return time.Now().UnixNano()
 }
 
 // Domain Service: ควบคุมกฏการต่อสู้ (Battle Logic)
 // รับ Entity เข้ามา และสั่งงานผ่าน Method ของ Entity
-func SimulateFight(c1, c2 *entity.Cowboy) BattleResult {
-	// Seed random
-	rand.Seed(time.Now().UnixNano())
+// ผลลัพธ์ขึ้นกับ seed อย่างเดียว (seed + Cowboy ชุดเดิม + Rules เดิม = ผลเหมือนเดิมทุกครั้ง)
+// Rules ที่ใช้ถูกเก็บไว้ในผล (MaxTurns) ให้ replay ได้แม้ค่า config จะเปลี่ยนไปแล้ว
+func SimulateFight(c1, c2 *entity.Cowboy, seed int64, rules Rules) BattleResult {
+	return SimulateFightLive(c1, c2, seed, rules, nil)
+}
+
+// EventObserver : ถูกเรียกทันทีที่แต่ละ Event เกิดขึ้น (เรียกแบบ sync ถ้า block engine ก็รอ)
+type EventObserver func(BattleEvent)
+
+// SimulateFightLive : เหมือน SimulateFight แต่แจ้ง observe ทีละ Event ระหว่างดวล (ใช้ถ่ายทอดสด)
+// observe ไม่มีผลกับผลการดวล seed เดิมได้ผลเดิมเสมอ
+func SimulateFightLive(c1, c2 *entity.Cowboy, seed int64, rules Rules, observe EventObserver) BattleResult {
+	result := simulate(c1, c2, seed, rules, observe)
+	result.Logs = RenderLogs(result.Events)
+	return result
+}
+
+// simulate : ตัว engine จริง (ยังไม่ render Logs เพื่อให้รันจำนวนมากๆ ได้เร็ว)
+func simulate(c1, c2 *entity.Cowboy, seed int64, rules Rules, observe EventObserver) BattleResult {
+	// ใช้ random source ของตัวเอง ไม่แตะ global source
+	rng := rand.New(rand.NewSource(seed))
+
+	var events []BattleEvent
+	emit := func(t EventType, turn int, attacker, defender *entity.Cowboy, dmg int) {
+		e := BattleEvent{
+			Type:         t,
+			Turn:         turn,
+			AttackerID:   attacker.ID,
+			AttackerName: attacker.Name,
+			DefenderID:   defender.ID,
+			DefenderName: defender.Name,
+			Damage:       dmg,
+			AttackerHP:   attacker.Health,
+			DefenderHP:   defender.Health,
+		}
+		events = append(events, e)
+		if observe != nil {
+			observe(e)
+		}
+	}
+
+	result := BattleResult{
+		Fighter1ID:   c1.ID,
+		Fighter1Name: c1.Name,
+		Fighter2ID:   c2.ID,
+		Fighter2Name: c2.Name,
+		// ถ่ายไว้ก่อนเริ่ม เพราะ Health จะลดระหว่างดวล
+		Fighter1Stats: SnapshotOf(c1),
+		Fighter2Stats: SnapshotOf(c2),
+		Result:        ResultDraw,
+		MaxTurns:      rules.maxTurns(),
+		Seed:          seed,
+	}
 
-	var logs []string
-	logs = append(logs, fmt.Sprintf("🔥 Match Start: %s (HP:%d) VS %s (HP:%d)", c1.Name, c1.Health, c2.Name, c2.Health))
+	emit(EventMatchStart, 0, c1, c2, 0)
 
 	// สร้างตัวแปร pointer ชั่วคราวเพื่อสลับเทิร์น (Attacker / Defender)
 	// เราใช้ตัวจริงเลยเพราะ Cowboy เป็น Pointer อยู่แล้ว และเรามี Method TakeDamage คุม State
@@ -28,42 +152,48 @@ func SimulateFight(c1, c2 *entity.Cowboy) BattleResult {
 
 	if c1.Speed >= c2.Speed {
 		attacker, defender = c1, c2
-		logs = append(logs, fmt.Sprintf("⚡ %s is faster!", c1.Name))
 	} else {
 		attacker, defender = c2, c1
-		logs = append(logs, fmt.Sprintf("⚡ %s is faster!", c2.Name))
 	}
+	emit(EventInitiative, 0, attacker, defender, 0)
 
-	turn := 1
-
-	// วนลูปจนกว่าจะมีฝ่ายใดฝ่ายหนึ่งตาย (ใช้ Method IsDead เช็ค)
-	for !c1.IsDead() && !c2.IsDead() {
-		logs = append(logs, fmt.Sprintf("--- Turn %d ---", turn))
+	// วนลูปจนกว่าจะมีฝ่ายใดฝ่ายหนึ่งตาย หรือครบจำนวนเทิร์นสูงสุด
+	for turn := 1; turn <= result.MaxTurns; turn++ {
+		result.Turns = turn
+		emit(EventTurnStart, turn, attacker, defender, 0)
 
 		// คำนวณโอกาสแม่นยำ
-		if rand.Float64() <= attacker.Accuracy {
+		if rng.Float64() <= attacker.Accuracy {
 			// คำนวณ Damage (Variance +/- 20%)
 			variance := float64(attacker.Damage) * 0.2
-			dmg := attacker.Damage + rand.Intn(int(variance)*2+1) - int(variance)
+			dmg := attacker.Damage + rng.Intn(int(variance)*2+1) - int(variance)
 
 			// 💥 เรียกใช้ Logic ภายใน Entity ให้รับดาเมจ
 			defender.TakeDamage(dmg)
 
-			logs = append(logs, fmt.Sprintf("💥 %s hits %s for %d (HP left: %d)",
-				attacker.Name, defender.Name, dmg, defender.Health))
+			emit(EventHit, turn, attacker, defender, dmg)
 		} else {
-			logs = append(logs, fmt.Sprintf("💨 %s missed!", attacker.Name))
+			emit(EventMiss, turn, attacker, defender, 0)
 		}
 
 		// เช็คจบเกมทันทีหลังโดนยิง
 		if defender.IsDead() {
+			emit(EventKnockout, turn, attacker, defender, 0)
+			result.Result = ResultWin
+			result.WinnerID = attacker.ID
+			result.Winner = attacker.Name
 			break
 		}
 
 		// สลับฝั่ง
 		attacker, defender = defender, attacker
-		turn++
 	}
 
-	return BattleResult{Winner: attacker.Name, Logs: logs}
+	// ครบเทิร์นแล้วยังไม่มีใครล้ม = เสมอ
+	if result.IsDraw() {
+		emit(EventTurnLimit, result.Turns, c1, c2, 0)
+	}
+
+	result.Events = events
+	return result
 }
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"api/services/arena/internal/core/domain/entity"
+)
+
+// go test ./internal/core/domain -run TestSimulateFightGolden -update : เขียนไฟล์ golden ใหม่หลังตั้งใจเปลี่ยน engine
+var update = flag.Bool("update", false, "rewrite testdata/*.golden")
+
+func TestSimulateFightGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		c1, c2 entity.Cowboy
+		seed   int64
+		rules  Rules
+	}{
+		{
+			name:  "knockout",
+			c1:    entity.Cowboy{ID: "kid", Name: "Kid", Health: 100, Damage: 30, Speed: 12, Accuracy: 0.8, Version: 3},
+			c2:    entity.Cowboy{ID: "doc", Name: "Doc", Health: 90, Damage: 25, Speed: 10, Accuracy: 0.7, Version: 1},
+			seed:  42,
+			rules: Rules{},
+		},
+		{
+			name:  "faster_fighter_2_shoots_first",
+			c1:    entity.Cowboy{ID: "kid", Name: "Kid", Health: 60, Damage: 20, Speed: 5, Accuracy: 0.6},
+			c2:    entity.Cowboy{ID: "doc", Name: "Doc", Health: 60, Damage: 20, Speed: 15, Accuracy: 0.6},
+			seed:  7,
+			rules: Rules{},
+		},
+		{
+			name:  "turn_limit_draw",
+			c1:    entity.Cowboy{ID: "kid", Name: "Kid", Health: 100, Damage: 5, Speed: 10, Accuracy: 0.5},
+			c2:    entity.Cowboy{ID: "doc", Name: "Doc", Health: 100, Damage: 5, Speed: 10, Accuracy: 0.5},
+			seed:  2024,
+			rules: Rules{MaxTurns: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c1, c2 := tt.c1, tt.c2
+			res := SimulateFight(&c1, &c2, tt.seed, tt.rules)
+
+			got, err := json.MarshalIndent(res, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			path := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("SimulateFight output changed for seed %d; if intended, rerun with -update\ngot:\n%s", tt.seed, got)
+			}
+
+			// ถ่ายทอดสดต้องเห็น Event ชุดเดียวกันตามลำดับ
+			c1, c2 = tt.c1, tt.c2
+			var observed []BattleEvent
+			live := SimulateFightLive(&c1, &c2, tt.seed, tt.rules, func(e BattleEvent) { observed = append(observed, e) })
+			liveJSON, _ := json.Marshal(live.Events)
+			observedJSON, _ := json.Marshal(observed)
+			resJSON, _ := json.Marshal(res.Events)
+			if !bytes.Equal(liveJSON, resJSON) || !bytes.Equal(observedJSON, resJSON) {
+				t.Fatal("SimulateFightLive diverged from SimulateFight with the same seed")
+			}
+		})
+	}
+}
+
+func TestSimulateFightStoresRules(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		want  int
+	}{
+		{Rules{}, DefaultMaxTurns},
+		{Rules{MaxTurns: -1}, DefaultMaxTurns},
+		{Rules{MaxTurns: 3}, 3},
+	}
+	for _, tt := range tests {
+		c1 := entity.Cowboy{ID: "kid", Name: "Kid", Health: 100, Damage: 1, Speed: 10, Accuracy: 0.5}
+		c2 := entity.Cowboy{ID: "doc", Name: "Doc", Health: 100, Damage: 1, Speed: 10, Accuracy: 0.5}
+		res := SimulateFight(&c1, &c2, 1, tt.rules)
+		if res.MaxTurns != tt.want {
+			t.Errorf("rules %+v stored MaxTurns %d, want %d", tt.rules, res.MaxTurns, tt.want)
+		}
+		if res.Turns > res.MaxTurns {
+			t.Errorf("rules %+v played %d turns, over the limit", tt.rules, res.Turns)
+		}
+	}
+}
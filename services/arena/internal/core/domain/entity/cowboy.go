@@ -7,4 +7,11 @@ type Cowboy struct {
 	Damage   int
 	Speed    int
 	Accuracy float64
+	Version  int // version ของค่าสถานะฝั่ง Duelist (0 = ไม่ทราบ)
+}
+
+// Clone : สำเนาใหม่ (HP เต็ม) เอาไว้ดวลหลายรอบโดยไม่กระทบตัวต้นฉบับ
+func (c *Cowboy) Clone() *Cowboy {
+	cp := *c
+	return &cp
 }
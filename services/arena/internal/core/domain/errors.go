@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+// Domain Errors: ให้ Adapter ฝั่งขาเข้าเอาไปแปลงเป็น status code เอง
+var (
+	ErrBattleNotFound     = errors.New("battle not found")
+	ErrSeriesNotFound     = errors.New("series not found")
+	ErrTournamentNotFound = errors.New("tournament not found")
+	ErrRatingNotFound     = errors.New("rating not found")
+	ErrCowboyNotFound     = errors.New("cowboy not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrConflict           = errors.New("conflict")
+)
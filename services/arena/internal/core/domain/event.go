@@ -0,0 +1,60 @@
+package domain
+
+import "fmt"
+
+// EventType : ชนิดของเหตุการณ์ที่เกิดขึ้นระหว่างการดวล
+type EventType string
+
+const (
+	EventMatchStart EventType = "match_start"
+	EventInitiative EventType = "initiative"
+	EventTurnStart  EventType = "turn_start"
+	EventHit        EventType = "hit"
+	EventMiss       EventType = "miss"
+	EventKnockout   EventType = "knockout"
+	EventTurnLimit  EventType = "turn_limit" // ครบเทิร์นสูงสุด = เสมอ
+)
+
+// Value Object: เหตุการณ์ 1 จังหวะในการดวล (Attacker = ฝ่ายที่ลงมือ, Defender = ฝ่ายที่ถูกกระทำ)
+// match_start / turn_limit ใช้ Attacker/Defender เป็น fighter 1 / fighter 2 พร้อม HP ตอนนั้น
+type BattleEvent struct {
+	Type         EventType `json:"type"`
+	Turn         int       `json:"turn"`
+	AttackerID   string    `json:"attacker_id"`
+	AttackerName string    `json:"attacker_name"`
+	DefenderID   string    `json:"defender_id"`
+	DefenderName string    `json:"defender_name"`
+	Damage       int       `json:"damage,omitempty"`
+	AttackerHP   int       `json:"attacker_hp"`
+	DefenderHP   int       `json:"defender_hp"`
+}
+
+// Text : แปลง Event เป็นข้อความให้คนอ่าน
+func (e BattleEvent) Text() string {
+	switch e.Type {
+	case EventMatchStart:
+		return fmt.Sprintf("🔥 Match Start: %s (HP:%d) VS %s (HP:%d)", e.AttackerName, e.AttackerHP, e.DefenderName, e.DefenderHP)
+	case EventInitiative:
+		return fmt.Sprintf("⚡ %s is faster!", e.AttackerName)
+	case EventTurnStart:
+		return fmt.Sprintf("--- Turn %d ---", e.Turn)
+	case EventHit:
+		return fmt.Sprintf("💥 %s hits %s for %d (HP left: %d)", e.AttackerName, e.DefenderName, e.Damage, e.DefenderHP)
+	case EventMiss:
+		return fmt.Sprintf("💨 %s missed!", e.AttackerName)
+	case EventKnockout:
+		return fmt.Sprintf("☠️ %s is down! %s wins", e.DefenderName, e.AttackerName)
+	case EventTurnLimit:
+		return fmt.Sprintf("⏱️ Turn limit reached after %d turns: %s (HP:%d) and %s (HP:%d) draw", e.Turn, e.AttackerName, e.AttackerHP, e.DefenderName, e.DefenderHP)
+	}
+	return string(e.Type)
+}
+
+// RenderLogs : แปลง Event ทั้งหมดเป็น Log แบบข้อความ (บรรทัดละ Event)
+func RenderLogs(events []BattleEvent) []string {
+	logs := make([]string, 0, len(events))
+	for _, e := range events {
+		logs = append(logs, e.Text())
+	}
+	return logs
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// HistorySort : ลำดับของประวัติการดวล
+type HistorySort string
+
+const (
+	SortNewest   HistorySort = "newest" // ล่าสุดก่อน (default)
+	SortOldest   HistorySort = "oldest"
+	SortLongest  HistorySort = "longest"  // เทิร์นมากสุดก่อน
+	SortShortest HistorySort = "shortest" // เทิร์นน้อยสุดก่อน
+)
+
+// HistoryFilter : เงื่อนไขค้นประวัติ (ค่าว่าง = ไม่กรอง)
+type HistoryFilter struct {
+	Limit        int
+	Cursor       string // next_cursor ของหน้าก่อน (ว่าง = หน้าแรก)
+	FighterID    string // battle ที่ Cowboy ตัวนี้ได้ดวล (ฝั่งไหนก็ได้)
+	OpponentID   string // ใช้คู่กับ FighterID = เฉพาะ battle ระหว่างสองคนนี้
+	WinnerID     string
+	TournamentID uint
+	SeriesID     uint
+	From         time.Time // CreatedAt >= From
+	To           time.Time // CreatedAt < To
+	Sort         HistorySort
+}
+
+// Validate : เช็คเงื่อนไขที่ขัดกันเอง (Sort ว่างถือว่า newest)
+func (f *HistoryFilter) Validate() error {
+	switch f.Sort {
+	case "", SortNewest, SortOldest, SortLongest, SortShortest:
+	default:
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidArgument, f.Sort)
+	}
+	if f.OpponentID != "" && f.FighterID == "" {
+		return fmt.Errorf("%w: opponent_id requires fighter_id", ErrInvalidArgument)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// HistoryCursor : ตำแหน่ง battle ตัวสุดท้ายของหน้าก่อน (keyset ตาม Sort)
+type HistoryCursor struct {
+	Sort  HistorySort `json:"s"`
+	Turns int         `json:"t,omitempty"` // ใช้เฉพาะ sort ตามจำนวนเทิร์น
+	ID    uint        `json:"id"`
+}
+
+// HistoryPage : ผลลัพธ์ 1 หน้า (NextCursor ว่าง = หน้าสุดท้าย)
+type HistoryPage struct {
+	Battles    []BattleResult `json:"battles"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
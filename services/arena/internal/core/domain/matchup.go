@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"api/services/arena/internal/core/domain/entity"
+)
+
+const (
+	DefaultMatchupSamples = 2000
+	MaxMatchupSamples     = 100000
+)
+
+// Distribution : สรุปการกระจายของค่าหนึ่งจากทุกรอบที่จำลอง
+type Distribution struct {
+	Mean float64 `json:"mean"`
+	Min  int     `json:"min"`
+	P10  int     `json:"p10"`
+	P50  int     `json:"p50"`
+	P90  int     `json:"p90"`
+	Max  int     `json:"max"`
+}
+
+// Matchup : ผลประเมินโอกาสชนะจากการจำลองซ้ำหลายรอบ (ไม่บันทึกลง DB)
+type Matchup struct {
+	Fighter1ID             string       `json:"fighter_1_id"`
+	Fighter1Name           string       `json:"fighter_1_name"`
+	Fighter2ID             string       `json:"fighter_2_id"`
+	Fighter2Name           string       `json:"fighter_2_name"`
+	Samples                int          `json:"samples"`   // จำนวนรอบที่ขอ
+	Completed              int          `json:"completed"` // จำนวนรอบที่จำลองจริง (น้อยกว่า Samples ถ้าหมดเวลา)
+	TimedOut               bool         `json:"timed_out"`
+	Fighter1WinProbability float64      `json:"fighter_1_win_probability"`
+	Fighter2WinProbability float64      `json:"fighter_2_win_probability"`
+	DrawRate               float64      `json:"draw_rate"`
+	Turns                  Distribution `json:"turns"`
+	Fighter1Damage         Distribution `json:"fighter_1_damage"` // ดาเมจที่ Fighter1 ทำได้ต่อ 1 นัด
+	Fighter2Damage         Distribution `json:"fighter_2_damage"`
+}
+
+// ValidateSamples : จำนวนรอบต้องอยู่ใน 1..MaxMatchupSamples
+func ValidateSamples(n int) error {
+	if n < 1 || n > MaxMatchupSamples {
+		return fmt.Errorf("%w: samples must be between 1 and %d", ErrInvalidArgument, MaxMatchupSamples)
+	}
+	return nil
+}
+
+// EstimateMatchup : Monte Carlo จำลองการดวลซ้ำ samples รอบ หรือจน ctx หมดเวลา/ถูกยกเลิก (แล้วแต่อะไรถึงก่อน)
+// seed ของแต่ละรอบสุ่มต่อจาก seed ที่ให้มา ผลจึง replay ได้ถ้าไม่หมดเวลากลางทาง
+func EstimateMatchup(ctx context.Context, c1, c2 *entity.Cowboy, samples int, seed int64, rules Rules) Matchup {
+	rng := rand.New(rand.NewSource(seed))
+	m := Matchup{
+		Fighter1ID:   c1.ID,
+		Fighter1Name: c1.Name,
+		Fighter2ID:   c2.ID,
+		Fighter2Name: c2.Name,
+		Samples:      samples,
+	}
+
+	turns := make([]int, 0, samples)
+	dmg1 := make([]int, 0, samples)
+	dmg2 := make([]int, 0, samples)
+	wins1, wins2, draws := 0, 0, 0
+
+	for i := 0; i < samples; i++ {
+		// เช็ค ctx ทุก 64 รอบ (ไม่ต้องเช็คทุกรอบ)
+		if i%64 == 0 && ctx.Err() != nil {
+			m.TimedOut = true
+			break
+		}
+
+		res := simulate(c1.Clone(), c2.Clone(), rng.Int63(), rules, nil)
+		switch {
+		case res.IsDraw():
+			draws++
+		case res.WinnerID == c1.ID:
+			wins1++
+		default:
+			wins2++
+		}
+
+		t1, t2 := res.Totals()
+		turns = append(turns, res.Turns)
+		dmg1 = append(dmg1, t1.DamageDealt)
+		dmg2 = append(dmg2, t2.DamageDealt)
+	}
+
+	m.Completed = len(turns)
+	if m.Completed == 0 {
+		return m
+	}
+	n := float64(m.Completed)
+	m.Fighter1WinProbability = float64(wins1) / n
+	m.Fighter2WinProbability = float64(wins2) / n
+	m.DrawRate = float64(draws) / n
+	m.Turns = distribution(turns)
+	m.Fighter1Damage = distribution(dmg1)
+	m.Fighter2Damage = distribution(dmg2)
+	return m
+}
+
+// distribution : สรุปค่าเฉลี่ยและ percentile (nearest-rank) ของข้อมูล
+func distribution(values []int) Distribution {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	sum := 0
+	for _, v := range sorted {
+		sum += v
+	}
+	pct := func(p int) int {
+		idx := (p*len(sorted)+99)/100 - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return sorted[idx]
+	}
+
+	return Distribution{
+		Mean: float64(sum) / float64(len(sorted)),
+		Min:  sorted[0],
+		P10:  pct(10),
+		P50:  pct(50),
+		P90:  pct(90),
+		Max:  sorted[len(sorted)-1],
+	}
+}
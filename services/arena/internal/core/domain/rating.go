@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultRating : คะแนนเริ่มต้นของ Cowboy ที่ยังไม่เคยดวล
+	DefaultRating = 1500.0
+	// DefaultKFactor : ค่า K ของ Elo (ยิ่งมาก คะแนนยิ่งแกว่งแรงต่อ 1 นัด)
+	DefaultKFactor = 32.0
+)
+
+// Entity: อันดับ Elo ของ Cowboy 1 ตัว
+type Rating struct {
+	Rank      int       `json:"rank,omitempty"` // เติมเฉพาะตอนดึงเป็น leaderboard
+	CowboyID  string    `json:"cowboy_id"`
+	Name      string    `json:"name"`
+	Rating    float64   `json:"rating"`
+	Games     int       `json:"games"`
+	Wins      int       `json:"wins"`
+	Losses    int       `json:"losses"`
+	Draws     int       `json:"draws"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewRating : Cowboy ที่ยังไม่มีประวัติ
+func NewRating(cowboyID, name string) *Rating {
+	return &Rating{CowboyID: cowboyID, Name: name, Rating: DefaultRating}
+}
+
+// Value Object: การเปลี่ยนคะแนน 1 ครั้ง (ใช้ดูกราฟคะแนนย้อนหลัง)
+type RatingChange struct {
+	CowboyID   string    `json:"cowboy_id"`
+	BattleID   uint      `json:"battle_id"`
+	OpponentID string    `json:"opponent_id"`
+	Before     float64   `json:"before"`
+	After      float64   `json:"after"`
+	Delta      float64   `json:"delta"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// LeaderboardFilter : เงื่อนไขดึง leaderboard
+type LeaderboardFilter struct {
+	Limit        int
+	Offset       int
+	MinGames     int    // ตัดคนที่ดวลน้อยกว่านี้ออก
+	NameContains string // ค้นหาจากชื่อ
+}
+
+// LeaderboardPage : ผลลัพธ์ 1 หน้า (NextOffset = 0 คือหน้าสุดท้าย)
+type LeaderboardPage struct {
+	Ratings    []Rating `json:"ratings"`
+	NextOffset int      `json:"next_offset,omitempty"`
+}
+
+// ExpectedScore : โอกาสชนะที่คาดไว้ของฝ่าย a เมื่อเจอ b (0..1)
+func ExpectedScore(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// ApplyElo : อัปเดตคะแนนทั้งสองฝ่ายจากผลการดวล แล้วคืนประวัติการเปลี่ยนแปลง
+// r1 / r2 ต้องเป็นของ Fighter1 / Fighter2 ของ result ตามลำดับ
+func ApplyElo(r1, r2 *Rating, result *BattleResult, k float64) (RatingChange, RatingChange) {
+	if k <= 0 {
+		k = DefaultKFactor
+	}
+
+	// แต้มจริง: ชนะ 1 เสมอ 0.5 แพ้ 0
+	s1 := 0.5
+	switch {
+	case result.IsDraw():
+		r1.Draws++
+		r2.Draws++
+	case result.WinnerID == r1.CowboyID:
+		s1 = 1
+		r1.Wins++
+		r2.Losses++
+	default:
+		s1 = 0
+		r2.Wins++
+		r1.Losses++
+	}
+
+	e1 := ExpectedScore(r1.Rating, r2.Rating)
+	delta := k * (s1 - e1)
+
+	c1 := RatingChange{CowboyID: r1.CowboyID, BattleID: result.ID, OpponentID: r2.CowboyID, Before: r1.Rating, Delta: delta}
+	c2 := RatingChange{CowboyID: r2.CowboyID, BattleID: result.ID, OpponentID: r1.CowboyID, Before: r2.Rating, Delta: -delta}
+
+	r1.Rating += delta
+	r2.Rating -= delta
+	r1.Games++
+	r2.Games++
+	r1.Name, r2.Name = result.Fighter1Name, result.Fighter2Name
+
+	c1.After, c2.After = r1.Rating, r2.Rating
+	return c1, c2
+}
+
+// RevertElo : ย้อนผลของ battle ที่ถูกยกเลิก (เช่น รอบทัวร์นาเมนต์ที่เล่นใหม่) ด้วย change ที่เคยบันทึกไว้
+// คืนประวัติฝั่งย้อน (Delta ติดลบของเดิม) ให้ ladder ยังตรวจย้อนหลังได้
+func RevertElo(r *Rating, change RatingChange, result *BattleResult) RatingChange {
+	rev := RatingChange{CowboyID: r.CowboyID, BattleID: change.BattleID, OpponentID: change.OpponentID, Before: r.Rating, Delta: -change.Delta}
+
+	r.Rating -= change.Delta
+	r.Games--
+	switch {
+	case result.IsDraw():
+		r.Draws--
+	case result.WinnerID == r.CowboyID:
+		r.Wins--
+	default:
+		r.Losses--
+	}
+
+	rev.After = r.Rating
+	return rev
+}
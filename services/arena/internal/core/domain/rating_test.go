@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestExpectedScore(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b float64
+		want float64
+	}{
+		{"equal ratings", 1500, 1500, 0.5},
+		{"400 points better", 1900, 1500, 10.0 / 11.0},
+		{"400 points worse", 1500, 1900, 1.0 / 11.0},
+		{"800 points better", 2300, 1500, 100.0 / 101.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpectedScore(tt.a, tt.b); !approx(got, tt.want) {
+				t.Fatalf("ExpectedScore(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			// สองฝั่งรวมกันต้องได้ 1 เสมอ
+			if sum := ExpectedScore(tt.a, tt.b) + ExpectedScore(tt.b, tt.a); !approx(sum, 1) {
+				t.Fatalf("expected scores sum to %v, want 1", sum)
+			}
+		})
+	}
+}
+
+func TestApplyElo(t *testing.T) {
+	tests := []struct {
+		name      string
+		r1, r2    float64
+		result    BattleResult
+		k         float64
+		wantDelta float64 // การเปลี่ยนของ fighter 1 (fighter 2 ได้ค่าตรงข้าม)
+		wantW1    [3]int  // wins, losses, draws ของ fighter 1
+		wantW2    [3]int
+	}{
+		{
+			name:      "equal ratings, fighter 1 wins",
+			r1:        1500,
+			r2:        1500,
+			result:    BattleResult{Result: ResultWin, WinnerID: "a"},
+			k:         32,
+			wantDelta: 16,
+			wantW1:    [3]int{1, 0, 0},
+			wantW2:    [3]int{0, 1, 0},
+		},
+		{
+			name:      "equal ratings, fighter 2 wins",
+			r1:        1500,
+			r2:        1500,
+			result:    BattleResult{Result: ResultWin, WinnerID: "b"},
+			k:         32,
+			wantDelta: -16,
+			wantW1:    [3]int{0, 1, 0},
+			wantW2:    [3]int{1, 0, 0},
+		},
+		{
+			name:      "equal ratings draw changes nothing",
+			r1:        1500,
+			r2:        1500,
+			result:    BattleResult{Result: ResultDraw},
+			k:         32,
+			wantDelta: 0,
+			wantW1:    [3]int{0, 0, 1},
+			wantW2:    [3]int{0, 0, 1},
+		},
+		{
+			name:      "draw moves the favourite down",
+			r1:        1900,
+			r2:        1500,
+			result:    BattleResult{Result: ResultDraw},
+			k:         32,
+			wantDelta: 32 * (0.5 - 10.0/11.0),
+			wantW1:    [3]int{0, 0, 1},
+			wantW2:    [3]int{0, 0, 1},
+		},
+		{
+			name:      "upset is worth more",
+			r1:        1500,
+			r2:        1900,
+			result:    BattleResult{Result: ResultWin, WinnerID: "a"},
+			k:         32,
+			wantDelta: 32 * (1 - 1.0/11.0),
+			wantW1:    [3]int{1, 0, 0},
+			wantW2:    [3]int{0, 1, 0},
+		},
+		{
+			name:      "k <= 0 uses default",
+			r1:        1500,
+			r2:        1500,
+			result:    BattleResult{Result: ResultWin, WinnerID: "a"},
+			k:         0,
+			wantDelta: DefaultKFactor / 2,
+			wantW1:    [3]int{1, 0, 0},
+			wantW2:    [3]int{0, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Rating{CowboyID: "a", Rating: tt.r1}
+			b := &Rating{CowboyID: "b", Rating: tt.r2}
+			res := tt.result
+			res.ID, res.Fighter1ID, res.Fighter1Name, res.Fighter2ID, res.Fighter2Name = 7, "a", "Kid", "b", "Doc"
+
+			c1, c2 := ApplyElo(a, b, &res, tt.k)
+
+			if !approx(c1.Delta, tt.wantDelta) || !approx(c2.Delta, -tt.wantDelta) {
+				t.Fatalf("deltas = %v / %v, want %v / %v", c1.Delta, c2.Delta, tt.wantDelta, -tt.wantDelta)
+			}
+			if !approx(a.Rating, tt.r1+tt.wantDelta) || !approx(b.Rating, tt.r2-tt.wantDelta) {
+				t.Fatalf("ratings = %v / %v", a.Rating, b.Rating)
+			}
+			if c1.Before != tt.r1 || c1.After != a.Rating || c2.Before != tt.r2 || c2.After != b.Rating {
+				t.Fatalf("changes = %+v / %+v", c1, c2)
+			}
+			if c1.BattleID != 7 || c1.OpponentID != "b" || c2.OpponentID != "a" {
+				t.Fatalf("changes reference the wrong battle / opponent: %+v / %+v", c1, c2)
+			}
+			if got := [3]int{a.Wins, a.Losses, a.Draws}; got != tt.wantW1 {
+				t.Errorf("fighter 1 record = %v, want %v", got, tt.wantW1)
+			}
+			if got := [3]int{b.Wins, b.Losses, b.Draws}; got != tt.wantW2 {
+				t.Errorf("fighter 2 record = %v, want %v", got, tt.wantW2)
+			}
+			if a.Games != 1 || b.Games != 1 || a.Name != "Kid" || b.Name != "Doc" {
+				t.Errorf("games / names not updated: %+v / %+v", a, b)
+			}
+		})
+	}
+}
+
+func TestRevertEloUndoesApplyElo(t *testing.T) {
+	results := map[string]BattleResult{
+		"fighter 1 wins": {Result: ResultWin, WinnerID: "a"},
+		"fighter 2 wins": {Result: ResultWin, WinnerID: "b"},
+		"draw":           {Result: ResultDraw},
+	}
+	for name, res := range results {
+		t.Run(name, func(t *testing.T) {
+			res.ID, res.Fighter1ID, res.Fighter2ID = 7, "a", "b"
+			a := Rating{CowboyID: "a", Rating: 1620, Games: 4, Wins: 2, Losses: 1, Draws: 1}
+			b := Rating{CowboyID: "b", Rating: 1480, Games: 2, Wins: 1, Losses: 1}
+			a0, b0 := a, b
+
+			c1, c2 := ApplyElo(&a, &b, &res, 32)
+			rev1, rev2 := RevertElo(&a, c1, &res), RevertElo(&b, c2, &res)
+
+			if !approx(a.Rating, a0.Rating) || !approx(b.Rating, b0.Rating) {
+				t.Fatalf("ratings after revert = %v / %v, want %v / %v", a.Rating, b.Rating, a0.Rating, b0.Rating)
+			}
+			a.Rating, b.Rating, a.Name, b.Name = a0.Rating, b0.Rating, a0.Name, b0.Name
+			if a != a0 || b != b0 {
+				t.Fatalf("records after revert = %+v / %+v, want %+v / %+v", a, b, a0, b0)
+			}
+			if !approx(rev1.Delta, -c1.Delta) || rev1.Before != c1.After || rev2.BattleID != 7 || rev2.OpponentID != "a" {
+				t.Fatalf("revert changes = %+v / %+v", rev1, rev2)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"api/services/arena/internal/core/domain/entity"
+)
+
+// MaxBestOf : จำนวนเกมสูงสุดของซีรีส์ 1 ชุด
+const MaxBestOf = 9
+
+// Aggregate: ซีรีส์แบบ Best-of-N (แต่ละเกมคือ BattleResult 1 ตัว)
+type Series struct {
+	ID           uint           `json:"id"` // Repository เป็นคนกำหนดตอน Save
+	Fighter1ID   string         `json:"fighter_1_id"`
+	Fighter1Name string         `json:"fighter_1_name"`
+	Fighter2ID   string         `json:"fighter_2_id"`
+	Fighter2Name string         `json:"fighter_2_name"`
+	BestOf       int            `json:"best_of"`
+	Fighter1Wins int            `json:"fighter_1_wins"`
+	Fighter2Wins int            `json:"fighter_2_wins"`
+	Draws        int            `json:"draws"`
+	Result       Result         `json:"result"`
+	WinnerID     string         `json:"winner_id"` // ว่างถ้าเสมอ
+	Winner       string         `json:"winner"`    // ว่างถ้าเสมอ
+	Seed         int64          `json:"seed"`      // seed ของซีรีส์ ใช้สร้าง seed ของแต่ละเกม
+	Games        []BattleResult `json:"games"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// ValidateBestOf : N ต้องเป็นเลขคี่ 1..MaxBestOf (จะได้ตัดสินผู้ชนะได้เสมอถ้าไม่มีเกมเสมอ)
+func ValidateBestOf(n int) error {
+	if n < 1 || n > MaxBestOf || n%2 == 0 {
+		return fmt.Errorf("%w: best_of must be an odd number between 1 and %d", ErrInvalidArgument, MaxBestOf)
+	}
+	return nil
+}
+
+// Domain Service: เล่นซีรีส์จนมีคนชนะครบ N/2+1 เกม หรือเล่นครบ N เกม
+// แต่ละเกมใช้สำเนาใหม่ของ Cowboy (HP เต็มทุกเกม) และ seed ที่สุ่มต่อจาก seed ของซีรีส์
+func PlaySeries(c1, c2 *entity.Cowboy, bestOf int, seed int64, rules Rules) Series {
+	rng := rand.New(rand.NewSource(seed))
+	toWin := bestOf/2 + 1
+
+	s := Series{
+		Fighter1ID:   c1.ID,
+		Fighter1Name: c1.Name,
+		Fighter2ID:   c2.ID,
+		Fighter2Name: c2.Name,
+		BestOf:       bestOf,
+		Result:       ResultDraw,
+		Seed:         seed,
+	}
+
+	for game := 0; game < bestOf && s.Fighter1Wins < toWin && s.Fighter2Wins < toWin; game++ {
+		res := SimulateFight(c1.Clone(), c2.Clone(), rng.Int63(), rules)
+		switch {
+		case res.IsDraw():
+			s.Draws++
+		case res.WinnerID == c1.ID:
+			s.Fighter1Wins++
+		default:
+			s.Fighter2Wins++
+		}
+		s.Games = append(s.Games, res)
+	}
+
+	// ชนะมากกว่า = ชนะซีรีส์ (เกมเสมอเยอะจนแต้มเท่ากัน = ซีรีส์เสมอ)
+	switch {
+	case s.Fighter1Wins > s.Fighter2Wins:
+		s.Result, s.WinnerID, s.Winner = ResultWin, c1.ID, c1.Name
+	case s.Fighter2Wins > s.Fighter1Wins:
+		s.Result, s.WinnerID, s.Winner = ResultWin, c2.ID, c2.Name
+	}
+	return s
+}
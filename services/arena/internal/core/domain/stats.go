@@ -0,0 +1,87 @@
+package domain
+
+// FighterTotals : ยอดรวมของฝ่ายเดียวใน 1 battle (คำนวณจาก Events)
+type FighterTotals struct {
+	DamageDealt int
+	Shots       int
+	Hits        int
+}
+
+// Totals : ยอดรวมของ Fighter1 และ Fighter2 ตามลำดับ
+func (r *BattleResult) Totals() (f1, f2 FighterTotals) {
+	for _, e := range r.Events {
+		if e.Type != EventHit && e.Type != EventMiss {
+			continue
+		}
+		t := &f1
+		if e.AttackerID != r.Fighter1ID {
+			t = &f2
+		}
+		t.Shots++
+		if e.Type == EventHit {
+			t.Hits++
+			t.DamageDealt += e.Damage
+		}
+	}
+	return f1, f2
+}
+
+// HeadToHead : สถิติเมื่อเจอคู่ต่อสู้คนใดคนหนึ่ง (มองจากฝั่ง Cowboy เจ้าของสถิติ)
+type HeadToHead struct {
+	OpponentID   string `json:"opponent_id"`
+	OpponentName string `json:"opponent_name"`
+	Battles      int    `json:"battles"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+	Draws        int    `json:"draws"`
+	Turns        int    `json:"-"`
+	DamageDealt  int    `json:"damage_dealt"`
+	DamageTaken  int    `json:"damage_taken"`
+	Shots        int    `json:"-"`
+	Hits         int    `json:"-"`
+}
+
+// FighterStats : สถิติรวมของ Cowboy 1 ตัว
+type FighterStats struct {
+	CowboyID     string       `json:"cowboy_id"`
+	Battles      int          `json:"battles"` // อาจมากกว่า ชนะ+แพ้+เสมอ ถ้ามี record เก่าที่ระบุผู้ชนะไม่ได้
+	Wins         int          `json:"wins"`
+	Losses       int          `json:"losses"`
+	Draws        int          `json:"draws"`
+	WinRate      float64      `json:"win_rate"`
+	AverageTurns float64      `json:"average_turns"`
+	DamageDealt  int          `json:"damage_dealt"`
+	DamageTaken  int          `json:"damage_taken"`
+	Shots        int          `json:"shots"`
+	Hits         int          `json:"hits"`
+	Accuracy     float64      `json:"accuracy"` // ความแม่นที่เกิดขึ้นจริง (hits / shots)
+	HeadToHead   []HeadToHead `json:"head_to_head"`
+}
+
+// SummarizeStats : รวมสถิติรายคู่ต่อสู้ให้เป็นสถิติรวม
+func SummarizeStats(cowboyID string, h2h []HeadToHead) *FighterStats {
+	s := &FighterStats{CowboyID: cowboyID, HeadToHead: h2h}
+	turns := 0
+	for _, h := range h2h {
+		s.Battles += h.Battles
+		s.Wins += h.Wins
+		s.Losses += h.Losses
+		s.Draws += h.Draws
+		s.DamageDealt += h.DamageDealt
+		s.DamageTaken += h.DamageTaken
+		s.Shots += h.Shots
+		s.Hits += h.Hits
+		turns += h.Turns
+	}
+	if s.Battles > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.Battles)
+		s.AverageTurns = float64(turns) / float64(s.Battles)
+	}
+	if s.Shots > 0 {
+		s.Accuracy = float64(s.Hits) / float64(s.Shots)
+	}
+	if s.HeadToHead == nil {
+		s.HeadToHead = []HeadToHead{}
+	}
+	return s
+}
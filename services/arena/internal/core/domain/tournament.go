@@ -0,0 +1,457 @@
+package domain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+
+	"api/services/arena/internal/core/domain/entity"
+)
+
+// TournamentFormat : รูปแบบการแข่ง
+type TournamentFormat string
+
+const (
+	FormatSingleElimination TournamentFormat = "single_elimination"
+	FormatRoundRobin        TournamentFormat = "round_robin"
+)
+
+// Seeding : วิธีจัดลำดับมือวาง (seed 1 = มือวางอันดับ 1)
+type Seeding string
+
+const (
+	SeedingAsGiven Seeding = "as_given" // ตามลำดับ cowboy_ids ที่ส่งมา
+	SeedingRandom  Seeding = "random"   // สุ่มจาก seed ของทัวร์นาเมนต์ (replay ได้)
+)
+
+type TournamentStatus string
+
+const (
+	TournamentPending    TournamentStatus = "pending"
+	TournamentInProgress TournamentStatus = "in_progress"
+	TournamentCompleted  TournamentStatus = "completed"
+)
+
+type MatchStatus string
+
+const (
+	MatchPending   MatchStatus = "pending"
+	MatchCompleted MatchStatus = "completed"
+	MatchBye       MatchStatus = "bye" // ไม่มีคู่แข่ง ผ่านเข้ารอบอัตโนมัติ
+)
+
+const (
+	// MinParticipants / MaxParticipants : จำนวนผู้เข้าแข่งต่อ 1 ทัวร์นาเมนต์
+	MinParticipants = 2
+	MaxParticipants = 64
+
+	// MaxRematches : แพ้คัดออกห้ามเสมอ ถ้าเสมอจะดวลใหม่ไม่เกินเท่านี้ แล้วให้มือวางที่ดีกว่าผ่าน
+	MaxRematches = 3
+)
+
+// Value Object: ผู้เข้าแข่ง 1 คน
+type Participant struct {
+	Seed     int    `json:"seed"`
+	CowboyID string `json:"cowboy_id"`
+	Name     string `json:"name"`
+}
+
+// Entity: 1 คู่ในสาย (Fighter ว่าง = ยังไม่รู้ว่าใคร หรือเป็น bye)
+type TournamentMatch struct {
+	ID         uint        `json:"id"`
+	Round      int         `json:"round"`
+	Slot       int         `json:"slot"` // ลำดับคู่ในรอบ (เริ่มที่ 0)
+	Fighter1ID string      `json:"fighter_1_id"`
+	Fighter2ID string      `json:"fighter_2_id"`
+	WinnerID   string      `json:"winner_id"` // ว่าง + completed = เสมอ (round robin เท่านั้น)
+	BattleID   uint        `json:"battle_id"` // battle ที่ตัดสินผล (0 = ยังไม่เล่น / bye)
+	Status     MatchStatus `json:"status"`
+	Replays    int         `json:"replays"` // จำนวนครั้งที่ผลถูกล้างไปเล่นใหม่ (ใช้คำนวณ seed ให้ไม่ซ้ำของเดิม)
+}
+
+// Aggregate: ทัวร์นาเมนต์ทั้งสาย
+type Tournament struct {
+	ID           uint              `json:"id"` // Repository เป็นคนกำหนดตอน Save
+	Name         string            `json:"name"`
+	Format       TournamentFormat  `json:"format"`
+	Seeding      Seeding           `json:"seeding"`
+	Status       TournamentStatus  `json:"status"`
+	Seed         int64             `json:"seed"`
+	CurrentRound int               `json:"current_round"` // รอบล่าสุดที่เล่นจบแล้ว (0 = ยังไม่เริ่ม)
+	TotalRounds  int               `json:"total_rounds"`
+	WinnerID     string            `json:"winner_id"`
+	Winner       string            `json:"winner"`
+	Participants []Participant     `json:"participants"`
+	Matches      []TournamentMatch `json:"matches"`
+	Version      int               `json:"version"` // เพิ่มทุกครั้งที่บันทึกรอบ (กันสอง request เล่นรอบเดียวกันทับกัน)
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// Value Object: คะแนนสะสม (round robin: ชนะ 3 เสมอ 1 แพ้ 0)
+type Standing struct {
+	CowboyID string `json:"cowboy_id"`
+	Name     string `json:"name"`
+	Played   int    `json:"played"`
+	Wins     int    `json:"wins"`
+	Losses   int    `json:"losses"`
+	Draws    int    `json:"draws"`
+	Points   int    `json:"points"`
+}
+
+// RoundBattle : battle ที่เกิดขึ้นใน 1 คู่ (ยังไม่มี ID จนกว่า Repository จะ Save)
+type RoundBattle struct {
+	Match  *TournamentMatch
+	Battle BattleResult
+}
+
+// NewTournament : สร้างทัวร์นาเมนต์พร้อมสายการแข่งทั้งหมด
+// cowboys ต้องเรียงตามลำดับที่ client ส่งมา (ใช้เป็นมือวางถ้า Seeding = as_given)
+func NewTournament(name string, format TournamentFormat, seeding Seeding, cowboys []*entity.Cowboy, seed int64) (*Tournament, error) {
+	if len(cowboys) < MinParticipants || len(cowboys) > MaxParticipants {
+		return nil, fmt.Errorf("%w: a tournament needs %d to %d cowboys", ErrInvalidArgument, MinParticipants, MaxParticipants)
+	}
+	seen := make(map[string]bool, len(cowboys))
+	for _, c := range cowboys {
+		if seen[c.ID] {
+			return nil, fmt.Errorf("%w: cowboy %q is listed twice", ErrInvalidArgument, c.ID)
+		}
+		seen[c.ID] = true
+	}
+
+	order := append([]*entity.Cowboy(nil), cowboys...)
+	switch seeding {
+	case "", SeedingAsGiven:
+		seeding = SeedingAsGiven
+	case SeedingRandom:
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	default:
+		return nil, fmt.Errorf("%w: unknown seeding %q", ErrInvalidArgument, seeding)
+	}
+
+	t := &Tournament{
+		Name:    name,
+		Format:  format,
+		Seeding: seeding,
+		Status:  TournamentPending,
+		Seed:    seed,
+	}
+	for i, c := range order {
+		t.Participants = append(t.Participants, Participant{Seed: i + 1, CowboyID: c.ID, Name: c.Name})
+	}
+
+	switch format {
+	case "", FormatSingleElimination:
+		t.Format = FormatSingleElimination
+		t.buildElimination()
+	case FormatRoundRobin:
+		t.buildRoundRobin()
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidArgument, format)
+	}
+	return t, nil
+}
+
+// buildElimination : จัดสายแพ้คัดออกแบบมาตรฐาน (1 เจอมือวางท้ายสุด) เติม bye ให้มือวางบนจนครบกำลังของ 2
+func (t *Tournament) buildElimination() {
+	size := 1
+	for size < len(t.Participants) {
+		size *= 2
+	}
+	for n := size; n > 1; n /= 2 {
+		t.TotalRounds++
+	}
+
+	order := bracketOrder(size)
+	for round := 1; round <= t.TotalRounds; round++ {
+		for slot := 0; slot < size>>round; slot++ {
+			t.Matches = append(t.Matches, TournamentMatch{Round: round, Slot: slot, Status: MatchPending})
+		}
+	}
+
+	for slot := 0; slot < size/2; slot++ {
+		m := t.Match(1, slot)
+		m.Fighter1ID = t.participantID(order[2*slot])
+		m.Fighter2ID = t.participantID(order[2*slot+1])
+		if m.Fighter2ID == "" {
+			// ไม่มีคู่ = ผ่านเข้ารอบถัดไปเลย
+			m.Status = MatchBye
+			t.recordWinner(m, m.Fighter1ID)
+		}
+	}
+}
+
+// bracketOrder : ลำดับมือวางในสาย เช่น size 8 = [1 8 4 5 2 7 3 6]
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		n := len(order) * 2
+		next := make([]int, 0, n)
+		for _, s := range order {
+			next = append(next, s, n+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+// buildRoundRobin : ทุกคนเจอกันหมด 1 ครั้ง จัดรอบด้วย circle method (คนเป็นคี่ = มีคนว่าง 1 คนต่อรอบ)
+func (t *Tournament) buildRoundRobin() {
+	ids := make([]string, 0, len(t.Participants)+1)
+	for _, p := range t.Participants {
+		ids = append(ids, p.CowboyID)
+	}
+	if len(ids)%2 == 1 {
+		ids = append(ids, "")
+	}
+
+	n := len(ids)
+	t.TotalRounds = n - 1
+	for round := 1; round <= t.TotalRounds; round++ {
+		slot := 0
+		for i := 0; i < n/2; i++ {
+			a, b := ids[i], ids[n-1-i]
+			if a == "" || b == "" {
+				continue
+			}
+			t.Matches = append(t.Matches, TournamentMatch{Round: round, Slot: slot, Fighter1ID: a, Fighter2ID: b, Status: MatchPending})
+			slot++
+		}
+		// หมุนทุกคนยกเว้นคนแรก
+		last := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = last
+	}
+}
+
+func (t *Tournament) participantID(seed int) string {
+	if seed > len(t.Participants) {
+		return ""
+	}
+	return t.Participants[seed-1].CowboyID
+}
+
+// betterSeed : คืน ID ของคนที่เป็นมือวางอันดับดีกว่า (เลข seed น้อยกว่า)
+func (t *Tournament) betterSeed(a, b string) string {
+	pa, _ := t.Participant(a)
+	pb, _ := t.Participant(b)
+	if pb.Seed < pa.Seed {
+		return b
+	}
+	return a
+}
+
+// Participant : หาผู้เข้าแข่งจาก ID
+func (t *Tournament) Participant(cowboyID string) (Participant, bool) {
+	for _, p := range t.Participants {
+		if p.CowboyID == cowboyID {
+			return p, true
+		}
+	}
+	return Participant{}, false
+}
+
+// Match : หาคู่จากรอบและ slot (nil = ไม่มี)
+func (t *Tournament) Match(round, slot int) *TournamentMatch {
+	for i := range t.Matches {
+		if t.Matches[i].Round == round && t.Matches[i].Slot == slot {
+			return &t.Matches[i]
+		}
+	}
+	return nil
+}
+
+// RoundMatches : ทุกคู่ในรอบนั้น
+func (t *Tournament) RoundMatches(round int) []*TournamentMatch {
+	var ms []*TournamentMatch
+	for i := range t.Matches {
+		if t.Matches[i].Round == round {
+			ms = append(ms, &t.Matches[i])
+		}
+	}
+	return ms
+}
+
+// CowboyIDs : ID ของผู้เข้าแข่งทั้งหมด
+func (t *Tournament) CowboyIDs() []string {
+	ids := make([]string, 0, len(t.Participants))
+	for _, p := range t.Participants {
+		ids = append(ids, p.CowboyID)
+	}
+	return ids
+}
+
+// PlayRound : ดวลทุกคู่ที่ยังไม่เล่นในรอบถัดไป แล้วเลื่อนผู้ชนะเข้ารอบต่อไป
+// cowboys คือค่าพลังปัจจุบันของผู้เข้าแข่ง (key = CowboyID)
+func (t *Tournament) PlayRound(cowboys map[string]*entity.Cowboy, rules Rules) ([]RoundBattle, error) {
+	if t.Status == TournamentCompleted {
+		return nil, fmt.Errorf("%w: tournament is already completed", ErrConflict)
+	}
+
+	round := t.CurrentRound + 1
+	var played []RoundBattle
+	for _, m := range t.RoundMatches(round) {
+		if m.Status != MatchPending {
+			continue
+		}
+		c1, c2 := cowboys[m.Fighter1ID], cowboys[m.Fighter2ID]
+		if c1 == nil || c2 == nil {
+			return nil, fmt.Errorf("%w: missing fighter for round %d match %d", ErrCowboyNotFound, round, m.Slot)
+		}
+
+		// แพ้คัดออกต้องมีผู้ชนะ เสมอ = ดวลใหม่ (round robin เสมอได้)
+		attempts := 1
+		if t.Format == FormatSingleElimination {
+			attempts = MaxRematches + 1
+		}
+		var res BattleResult
+		for i := 0; i < attempts; i++ {
+			attempt := m.Replays*attempts + i
+			res = SimulateFight(c1.Clone(), c2.Clone(), MatchSeed(t.Seed, round, m.Slot, attempt), rules)
+			res.TournamentRound = round
+			played = append(played, RoundBattle{Match: m, Battle: res})
+			if !res.IsDraw() {
+				break
+			}
+		}
+
+		winner := res.WinnerID
+		if winner == "" && t.Format == FormatSingleElimination {
+			// เสมอครบทุกรอบแล้ว: ให้มือวางที่ดีกว่าผ่าน
+			winner = t.betterSeed(m.Fighter1ID, m.Fighter2ID)
+		}
+		m.Status = MatchCompleted
+		t.recordWinner(m, winner)
+	}
+
+	t.CurrentRound = round
+	t.Status = TournamentInProgress
+	if round == t.TotalRounds {
+		t.finish()
+	}
+	return played, nil
+}
+
+// ResetRound : ล้างผลตั้งแต่รอบ round จนถึงรอบล่าสุด เพื่อเล่นรอบนั้นใหม่
+// รอบหลังจากนั้นกลับเป็นยังไม่เล่น (แพ้คัดออกคู่ต่อสู้อาจเปลี่ยน) ต้อง advance ใหม่ทีละรอบ
+func (t *Tournament) ResetRound(round int) error {
+	if round < 1 || round > t.CurrentRound {
+		return fmt.Errorf("%w: round %d has not been played yet (latest played round is %d)", ErrConflict, round, t.CurrentRound)
+	}
+
+	for r := round; r <= t.CurrentRound; r++ {
+		for _, m := range t.RoundMatches(r) {
+			if m.Status != MatchCompleted {
+				continue
+			}
+			if t.Format == FormatSingleElimination {
+				t.propagate(m, "")
+			}
+			m.WinnerID = ""
+			m.BattleID = 0
+			m.Status = MatchPending
+			m.Replays++
+		}
+	}
+
+	t.CurrentRound = round - 1
+	t.Status = TournamentInProgress
+	t.WinnerID, t.Winner = "", ""
+	return nil
+}
+
+// MatchSeed : seed ของการดวล 1 ครั้งในสาย คำนวณจาก seed ของทัวร์นาเมนต์ (replay ทั้งสายซ้ำได้จาก seed เดียว)
+// attempt นับทั้งการดวลใหม่เพราะเสมอ และการล้างผลไปเล่นใหม่
+func MatchSeed(tournamentSeed int64, round, slot, attempt int) int64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, v := range []int64{tournamentSeed, int64(round), int64(slot), int64(attempt)} {
+		binary.BigEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+	return int64(h.Sum64())
+}
+
+// recordWinner : บันทึกผู้ชนะ และ (แพ้คัดออก) ส่งต่อไปรอบถัดไป
+func (t *Tournament) recordWinner(m *TournamentMatch, winnerID string) {
+	m.WinnerID = winnerID
+	if t.Format == FormatSingleElimination {
+		t.propagate(m, winnerID)
+	}
+}
+
+// propagate : ใส่ cowboyID ลงช่องของคู่ในรอบถัดไป (slot คู่ = Fighter1, slot คี่ = Fighter2)
+func (t *Tournament) propagate(m *TournamentMatch, cowboyID string) {
+	next := t.Match(m.Round+1, m.Slot/2)
+	if next == nil {
+		return
+	}
+	if m.Slot%2 == 0 {
+		next.Fighter1ID = cowboyID
+	} else {
+		next.Fighter2ID = cowboyID
+	}
+}
+
+// finish : ปิดทัวร์นาเมนต์และประกาศแชมป์
+func (t *Tournament) finish() {
+	t.Status = TournamentCompleted
+	switch t.Format {
+	case FormatSingleElimination:
+		if final := t.Match(t.TotalRounds, 0); final != nil {
+			t.WinnerID = final.WinnerID
+		}
+	case FormatRoundRobin:
+		if standings := t.Standings(); len(standings) > 0 {
+			t.WinnerID = standings[0].CowboyID
+		}
+	}
+	if p, ok := t.Participant(t.WinnerID); ok {
+		t.Winner = p.Name
+	}
+}
+
+// Standings : ตารางคะแนน เรียงตามแต้ม > จำนวนชนะ > มือวาง
+func (t *Tournament) Standings() []Standing {
+	table := make([]Standing, len(t.Participants))
+	index := make(map[string]int, len(t.Participants))
+	for i, p := range t.Participants {
+		table[i] = Standing{CowboyID: p.CowboyID, Name: p.Name}
+		index[p.CowboyID] = i
+	}
+
+	for _, m := range t.Matches {
+		if m.Status != MatchCompleted {
+			continue
+		}
+		a, b := &table[index[m.Fighter1ID]], &table[index[m.Fighter2ID]]
+		a.Played++
+		b.Played++
+		switch m.WinnerID {
+		case "":
+			a.Draws++
+			b.Draws++
+		case m.Fighter1ID:
+			a.Wins++
+			b.Losses++
+		default:
+			b.Wins++
+			a.Losses++
+		}
+	}
+
+	for i := range table {
+		table[i].Points = table[i].Wins*3 + table[i].Draws
+	}
+	// Participants เรียงตามมือวางอยู่แล้ว ใช้ stable sort ให้มือวางเป็นตัวตัดสินสุดท้าย
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points > table[j].Points
+		}
+		return table[i].Wins > table[j].Wins
+	})
+	return table
+}
@@ -0,0 +1,322 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"api/services/arena/internal/core/domain/entity"
+)
+
+// testCowboys : c1..cn ค่าพลังเท่ากันหมด (ลำดับมือวาง = ลำดับใน slice)
+func testCowboys(n int) []*entity.Cowboy {
+	cowboys := make([]*entity.Cowboy, n)
+	for i := range cowboys {
+		cowboys[i] = &entity.Cowboy{
+			ID:       fmt.Sprintf("c%d", i+1),
+			Name:     fmt.Sprintf("Cowboy %d", i+1),
+			Health:   100,
+			Damage:   10,
+			Speed:    10,
+			Accuracy: 0.5,
+		}
+	}
+	return cowboys
+}
+
+func cowboyMap(cowboys []*entity.Cowboy) map[string]*entity.Cowboy {
+	m := make(map[string]*entity.Cowboy, len(cowboys))
+	for _, c := range cowboys {
+		m[c.ID] = c
+	}
+	return m
+}
+
+func TestBracketOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{1, []int{1}},
+		{2, []int{1, 2}},
+		{4, []int{1, 4, 2, 3}},
+		{8, []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.size), func(t *testing.T) {
+			if got := bracketOrder(tt.size); !slices.Equal(got, tt.want) {
+				t.Fatalf("bracketOrder(%d) = %v, want %v", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEliminationBracket(t *testing.T) {
+	tests := []struct {
+		name        string
+		players     int
+		wantRounds  int
+		wantMatches int
+		// คู่รอบแรกตามลำดับ slot ("" = bye)
+		wantFirst [][2]string
+	}{
+		{"two players", 2, 1, 1, [][2]string{{"c1", "c2"}}},
+		{"three players, top seed gets a bye", 3, 2, 3, [][2]string{{"c1", ""}, {"c2", "c3"}}},
+		{"full bracket of four", 4, 2, 3, [][2]string{{"c1", "c4"}, {"c2", "c3"}}},
+		{"five players, three byes", 5, 3, 7, [][2]string{{"c1", ""}, {"c4", "c5"}, {"c2", ""}, {"c3", ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tour, err := NewTournament("cup", FormatSingleElimination, SeedingAsGiven, testCowboys(tt.players), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tour.TotalRounds != tt.wantRounds || len(tour.Matches) != tt.wantMatches {
+				t.Fatalf("rounds / matches = %d / %d, want %d / %d", tour.TotalRounds, len(tour.Matches), tt.wantRounds, tt.wantMatches)
+			}
+
+			first := tour.RoundMatches(1)
+			if len(first) != len(tt.wantFirst) {
+				t.Fatalf("first round has %d matches, want %d", len(first), len(tt.wantFirst))
+			}
+			for i, m := range first {
+				if got := [2]string{m.Fighter1ID, m.Fighter2ID}; got != tt.wantFirst[i] {
+					t.Errorf("slot %d = %v, want %v", i, got, tt.wantFirst[i])
+				}
+				// bye ผ่านเข้ารอบสองทันที
+				if m.Fighter2ID == "" {
+					if m.Status != MatchBye || m.WinnerID != m.Fighter1ID {
+						t.Errorf("slot %d bye = %+v", i, m)
+					}
+					next := tour.Match(2, m.Slot/2)
+					if got := []string{next.Fighter1ID, next.Fighter2ID}[m.Slot%2]; got != m.Fighter1ID {
+						t.Errorf("bye winner %s not propagated to round 2 (got %q)", m.Fighter1ID, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewTournamentRejects(t *testing.T) {
+	dup := testCowboys(2)
+	dup[1].ID = dup[0].ID
+	tests := []struct {
+		name    string
+		format  TournamentFormat
+		seeding Seeding
+		cowboys []*entity.Cowboy
+	}{
+		{"too few", FormatSingleElimination, SeedingAsGiven, testCowboys(1)},
+		{"too many", FormatSingleElimination, SeedingAsGiven, testCowboys(MaxParticipants + 1)},
+		{"duplicate cowboy", FormatSingleElimination, SeedingAsGiven, dup},
+		{"unknown format", "swiss", SeedingAsGiven, testCowboys(2)},
+		{"unknown seeding", FormatRoundRobin, "by_height", testCowboys(2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTournament("cup", tt.format, tt.seeding, tt.cowboys, 1); !errors.Is(err, ErrInvalidArgument) {
+				t.Fatalf("err = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestRandomSeedingIsReproducible(t *testing.T) {
+	a, _ := NewTournament("cup", FormatSingleElimination, SeedingRandom, testCowboys(8), 42)
+	b, _ := NewTournament("cup", FormatSingleElimination, SeedingRandom, testCowboys(8), 42)
+	if !slices.Equal(a.CowboyIDs(), b.CowboyIDs()) {
+		t.Fatalf("same seed gave different seeding: %v / %v", a.CowboyIDs(), b.CowboyIDs())
+	}
+}
+
+func TestRoundRobinSchedule(t *testing.T) {
+	tests := []struct {
+		players    int
+		wantRounds int
+	}{
+		{2, 1},
+		{3, 3},
+		{4, 3},
+		{5, 5},
+		{6, 5},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.players), func(t *testing.T) {
+			tour, err := NewTournament("league", FormatRoundRobin, SeedingAsGiven, testCowboys(tt.players), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tour.TotalRounds != tt.wantRounds {
+				t.Fatalf("rounds = %d, want %d", tour.TotalRounds, tt.wantRounds)
+			}
+
+			// ทุกคู่เจอกันครั้งเดียว และไม่มีใครลงสองนัดในรอบเดียว
+			pairs := make(map[[2]string]int)
+			for round := 1; round <= tour.TotalRounds; round++ {
+				busy := make(map[string]bool)
+				for _, m := range tour.RoundMatches(round) {
+					if busy[m.Fighter1ID] || busy[m.Fighter2ID] {
+						t.Fatalf("round %d schedules a cowboy twice", round)
+					}
+					busy[m.Fighter1ID], busy[m.Fighter2ID] = true, true
+					pair := [2]string{min(m.Fighter1ID, m.Fighter2ID), max(m.Fighter1ID, m.Fighter2ID)}
+					pairs[pair]++
+				}
+			}
+			if want := tt.players * (tt.players - 1) / 2; len(pairs) != want {
+				t.Fatalf("%d distinct pairs, want %d", len(pairs), want)
+			}
+			for pair, n := range pairs {
+				if n != 1 {
+					t.Errorf("%v meet %d times", pair, n)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayRoundUsesDerivedSeeds(t *testing.T) {
+	cowboys := testCowboys(4)
+	play := func() []RoundBattle {
+		tour, _ := NewTournament("cup", FormatSingleElimination, SeedingAsGiven, cowboys, 99)
+		battles, err := tour.PlayRound(cowboyMap(cowboys), Rules{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return battles
+	}
+
+	a, b := play(), play()
+	if len(a) != len(b) {
+		t.Fatalf("same seed played %d / %d battles", len(a), len(b))
+	}
+	for i := range a {
+		m := a[i].Match
+		if a[i].Battle.Seed != b[i].Battle.Seed || a[i].Battle.WinnerID != b[i].Battle.WinnerID {
+			t.Fatalf("battle %d differs between runs with the same tournament seed", i)
+		}
+		if a[i].Battle.TournamentRound != 1 {
+			t.Errorf("battle %d round = %d, want 1", i, a[i].Battle.TournamentRound)
+		}
+		if i == 0 || a[i-1].Match != m {
+			// ดวลครั้งแรกของคู่ = attempt 0
+			if want := MatchSeed(99, 1, m.Slot, 0); a[i].Battle.Seed != want {
+				t.Errorf("slot %d seed = %d, want %d", m.Slot, a[i].Battle.Seed, want)
+			}
+		}
+	}
+}
+
+func TestMatchSeed(t *testing.T) {
+	base := MatchSeed(7, 1, 0, 0)
+	if MatchSeed(7, 1, 0, 0) != base {
+		t.Fatal("MatchSeed is not deterministic")
+	}
+	others := []int64{
+		MatchSeed(8, 1, 0, 0),
+		MatchSeed(7, 2, 0, 0),
+		MatchSeed(7, 1, 1, 0),
+		MatchSeed(7, 1, 0, 1),
+	}
+	for i, s := range others {
+		if s == base {
+			t.Errorf("variant %d collides with the base seed", i)
+		}
+	}
+}
+
+func TestResetRound(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     TournamentFormat
+		played     int // จำนวนรอบที่เล่นไปก่อน reset
+		reset      int
+		wantErr    bool
+		wantActive int // CurrentRound หลัง reset
+	}{
+		{"latest round", FormatSingleElimination, 2, 2, false, 1},
+		{"earlier round clears later rounds", FormatSingleElimination, 3, 1, false, 0},
+		{"round robin earlier round", FormatRoundRobin, 3, 2, false, 1},
+		{"unplayed round", FormatSingleElimination, 1, 2, true, 1},
+		{"round zero", FormatRoundRobin, 1, 0, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cowboys := testCowboys(5)
+			tour, _ := NewTournament("cup", tt.format, SeedingAsGiven, cowboys, 3)
+			for i := 0; i < tt.played; i++ {
+				if _, err := tour.PlayRound(cowboyMap(cowboys), Rules{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			before := append([]TournamentMatch(nil), tour.Matches...)
+
+			err := tour.ResetRound(tt.reset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResetRound(%d) = %v, wantErr %v", tt.reset, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrConflict) || !slices.Equal(tour.Matches, before) {
+					t.Fatalf("rejected reset changed the bracket or returned %v", err)
+				}
+				return
+			}
+			if tour.CurrentRound != tt.wantActive || tour.WinnerID != "" || tour.Status != TournamentInProgress {
+				t.Fatalf("after reset: round %d winner %q status %s", tour.CurrentRound, tour.WinnerID, tour.Status)
+			}
+
+			for i, m := range tour.Matches {
+				old := before[i]
+				switch {
+				case m.Round < tt.reset || old.Status == MatchBye:
+					if m != old {
+						t.Errorf("round %d slot %d changed: %+v -> %+v", m.Round, m.Slot, old, m)
+					}
+				case m.Status != MatchPending || m.WinnerID != "" || m.BattleID != 0:
+					t.Errorf("round %d slot %d not cleared: %+v", m.Round, m.Slot, m)
+				case old.Status == MatchCompleted && m.Replays != old.Replays+1:
+					t.Errorf("round %d slot %d replays = %d, want %d", m.Round, m.Slot, m.Replays, old.Replays+1)
+				}
+				// แพ้คัดออก: คู่หลังรอบที่ล้างต้องไม่เหลือผู้ชนะเก่าค้าง (ยกเว้นที่มาจาก bye)
+				if tt.format == FormatSingleElimination && m.Round > tt.reset+1 && (m.Fighter1ID != "" || m.Fighter2ID != "") {
+					t.Errorf("round %d slot %d still has fighters %q / %q", m.Round, m.Slot, m.Fighter1ID, m.Fighter2ID)
+				}
+			}
+
+			// เล่นใหม่ได้จนจบ และรอบที่เล่นใหม่ใช้ seed ใหม่
+			for tour.Status != TournamentCompleted {
+				battles, err := tour.PlayRound(cowboyMap(cowboys), Rules{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, rb := range battles {
+					if rb.Match.Round == tt.reset && rb.Battle.Seed == MatchSeed(3, tt.reset, rb.Match.Slot, 0) {
+						t.Fatalf("replayed round %d slot %d reused its original seed", tt.reset, rb.Match.Slot)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEliminationDrawFallsBackToBetterSeed(t *testing.T) {
+	cowboys := testCowboys(2)
+	for _, c := range cowboys {
+		c.Damage = 1 // ไม่มีใครตายใน 1 เทิร์น = เสมอทุกครั้ง
+	}
+	tour, _ := NewTournament("cup", FormatSingleElimination, SeedingAsGiven, []*entity.Cowboy{cowboys[1], cowboys[0]}, 5)
+
+	battles, err := tour.PlayRound(cowboyMap(cowboys), Rules{MaxTurns: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(battles) != MaxRematches+1 {
+		t.Fatalf("played %d battles, want %d", len(battles), MaxRematches+1)
+	}
+	// c2 ถูกส่งมาก่อน = มือวาง 1
+	if tour.Status != TournamentCompleted || tour.WinnerID != "c2" {
+		t.Fatalf("status %s winner %q, want completed / c2", tour.Status, tour.WinnerID)
+	}
+}
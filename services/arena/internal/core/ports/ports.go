@@ -3,20 +3,83 @@ package ports
 import (
 	"api/services/arena/internal/core/domain"
 	"api/services/arena/internal/core/domain/entity"
+	"context"
+	"time"
 )
 
 // Secondary Port (Outbound) - สำหรับดึงข้อมูล Cowboy (เช่นจาก gRPC)
 type CowboyProvider interface {
-	GetCowboy(id string) (*entity.Cowboy, error)
+	GetCowboy(ctx context.Context, id string) (*entity.Cowboy, error)
+	// GetCowboys : ดึงหลายตัวในครั้งเดียว คืนตามลำดับ ids (ID ซ้ำได้สำเนาแยกกัน)
+	// ถ้ามีตัวไหนไม่เจอ = ErrCowboyNotFound
+	GetCowboys(ctx context.Context, ids []string) ([]*entity.Cowboy, error)
 }
 
 // Secondary Port (Outbound) - สำหรับเก็บผล (Database)
 type ArenaService interface {
-	Duel(fighter1ID, fighter2ID string) (*domain.BattleResult, error)
-	GetHistory(limit int, fighterID string) ([]domain.BattleResult, error)
+	Duel(ctx context.Context, fighter1ID, fighter2ID string) (*domain.BattleResult, error)
+	// DuelLive : เหมือน Duel แต่ส่ง Event ให้ onEvent ทีละจังหวะ เว้นแต่ละเทิร์นเท่ากับ pace (< 0 = ค่า default)
+	// client ตัดสายกลางทาง การดวลก็ยังจบและถูกบันทึกตามปกติ
+	DuelLive(ctx context.Context, fighter1ID, fighter2ID string, pace time.Duration, onEvent func(domain.BattleEvent)) (*domain.BattleResult, error)
+	// GetHistory : ประวัติทีละหน้าตาม filter (ใช้ NextCursor เดินต่อ)
+	GetHistory(ctx context.Context, filter domain.HistoryFilter) (*domain.HistoryPage, error)
+	GetBattle(ctx context.Context, id uint) (*domain.BattleResult, error)
+	// Series : ดวลแบบ Best-of-N แล้วบันทึกทุกเกม
+	Series(ctx context.Context, fighter1ID, fighter2ID string, bestOf int) (*domain.Series, error)
+	GetSeries(ctx context.Context, id uint) (*domain.Series, error)
+	GetFighterStats(ctx context.Context, cowboyID string) (*domain.FighterStats, error)
+	// Matchup : จำลองการดวลซ้ำเพื่อประเมินโอกาสชนะ (ไม่บันทึก)
+	// samples / budget เป็น 0 = ใช้ค่า default
+	Matchup(ctx context.Context, fighter1ID, fighter2ID string, samples int, budget time.Duration) (*domain.Matchup, error)
 }
 
 type BattleRepository interface {
-	Save(result *domain.BattleResult, f1, f2 string) error
-	GetHistory(limit int, fighterID string) ([]domain.BattleResult, error)
+	// Save : บันทึกผล แล้วเติม ID / CreatedAt กลับเข้าไปใน result
+	// battle ทุกตัวที่บันทึก (ทุก method) อัปเดตคะแนน Elo ของทั้งสองฝ่ายใน transaction เดียวกัน
+	Save(ctx context.Context, result *domain.BattleResult) error
+	FindByID(ctx context.Context, id uint) (*domain.BattleResult, error)
+	// GetHistory : คืนไม่เกิน filter.Limit ตัว ถัดจาก after (nil = ตั้งแต่ต้น) เรียงตาม filter.Sort
+	GetHistory(ctx context.Context, filter domain.HistoryFilter, after *domain.HistoryCursor) ([]domain.BattleResult, error)
+	// SaveSeries : บันทึกซีรีส์พร้อมทุกเกม (แต่ละเกมจะได้ ID และ SeriesID กลับไป)
+	SaveSeries(ctx context.Context, series *domain.Series) error
+	FindSeries(ctx context.Context, id uint) (*domain.Series, error)
+	// FighterStats : สถิติรวม + รายคู่ต่อสู้ จากทุก battle ที่ Cowboy ตัวนี้เคยดวล
+	FighterStats(ctx context.Context, cowboyID string) (*domain.FighterStats, error)
+}
+
+// Primary Port (Inbound) - ระบบทัวร์นาเมนต์
+type TournamentService interface {
+	// Create : cowboyIDs เรียงตามมือวาง (ถ้า seeding = as_given)
+	Create(ctx context.Context, name string, format domain.TournamentFormat, seeding domain.Seeding, cowboyIDs []string) (*domain.Tournament, error)
+	Get(ctx context.Context, id uint) (*domain.Tournament, error)
+	// Advance : ดวลทุกคู่ในรอบถัดไป
+	Advance(ctx context.Context, id uint) (*domain.Tournament, error)
+	// ReplayRound : ล้างผลตั้งแต่รอบ round (เล่นไปแล้วรอบไหนก็ได้) แล้วดวลรอบนั้นใหม่
+	ReplayRound(ctx context.Context, id uint, round int) (*domain.Tournament, error)
+}
+
+// Secondary Port (Outbound) - เก็บสถานะสายการแข่ง
+type TournamentRepository interface {
+	// Create : บันทึกทัวร์นาเมนต์และทุกคู่ (เติม ID กลับเข้าไป)
+	Create(ctx context.Context, t *domain.Tournament) error
+	FindByID(ctx context.Context, id uint) (*domain.Tournament, error)
+	// SaveRound : บันทึก battle ที่เพิ่งดวล + สถานะสายทั้งหมด ใน transaction เดียว
+	// t.Version ต้องตรงกับที่บันทึกไว้ ไม่งั้นคืน ErrConflict (มี request อื่นบันทึกไปก่อน) สำเร็จแล้ว Version เพิ่ม 1
+	// replayFrom > 0 = battle เดิมตั้งแต่รอบนั้นถูกแทนที่ ต้องย้อนคะแนนและตัดออกจากประวัติ
+	SaveRound(ctx context.Context, t *domain.Tournament, battles []domain.RoundBattle, replayFrom int) error
+}
+
+// Primary Port (Inbound) - ladder คะแนน Elo
+type RatingService interface {
+	Leaderboard(ctx context.Context, filter domain.LeaderboardFilter) (*domain.LeaderboardPage, error)
+	// GetRating : คะแนนปัจจุบัน + ประวัติย้อนหลังล่าสุด limit รายการ
+	GetRating(ctx context.Context, cowboyID string, limit int) (*domain.Rating, []domain.RatingChange, error)
+}
+
+// Secondary Port (Outbound) - อ่านคะแนนและประวัติ (การเขียนเกิดพร้อมกับการบันทึก battle)
+type RatingRepository interface {
+	// FindRating : ยังไม่เคยมีคะแนน = คืน nil, nil
+	FindRating(ctx context.Context, cowboyID string) (*domain.Rating, error)
+	Leaderboard(ctx context.Context, filter domain.LeaderboardFilter) ([]domain.Rating, error)
+	History(ctx context.Context, cowboyID string, limit int) ([]domain.RatingChange, error)
 }
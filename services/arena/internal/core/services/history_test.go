@@ -0,0 +1,164 @@
+package services
+
+import (
+	"api/services/arena/internal/adapters/repository"
+	"api/services/arena/internal/core/domain"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestHistoryCursorRoundTrip(t *testing.T) {
+	tests := []domain.HistoryCursor{
+		{Sort: domain.SortNewest, ID: 1},
+		{Sort: domain.SortOldest, ID: 4294967295},
+		{Sort: domain.SortLongest, Turns: 100, ID: 7},
+		{Sort: domain.SortShortest, Turns: 0, ID: 7},
+	}
+	for _, want := range tests {
+		t.Run(string(want.Sort), func(t *testing.T) {
+			cursor := encodeHistoryCursor(want)
+			got, err := decodeHistoryCursor(cursor, want.Sort)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != want {
+				t.Fatalf("decoded %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeHistoryCursor(t *testing.T) {
+	valid := encodeHistoryCursor(domain.HistoryCursor{Sort: domain.SortLongest, Turns: 5, ID: 9})
+	tests := []struct {
+		name    string
+		cursor  string
+		sort    domain.HistorySort
+		wantNil bool
+		wantErr bool
+	}{
+		{"empty = first page", "", domain.SortNewest, true, false},
+		{"valid", valid, domain.SortLongest, false, false},
+		{"issued for another sort", valid, domain.SortShortest, false, true},
+		{"not base64", "%%%", domain.SortNewest, false, true},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"newest","id":12}`)), domain.SortNewest, false, true},
+		{"not JSON", base64.RawURLEncoding.EncodeToString([]byte("id=1")), domain.SortNewest, false, true},
+		{"wrong field type", base64.RawURLEncoding.EncodeToString([]byte(`{"s":"newest","id":"x"}`)), domain.SortNewest, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeHistoryCursor(tt.cursor, tt.sort)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Fatalf("err = %v, want ErrInvalidArgument", err)
+			}
+			if (got == nil) != tt.wantNil && !tt.wantErr {
+				t.Fatalf("cursor = %+v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+// historyService : service ที่มี battle ตามจำนวนเทิร์นที่ให้ (ID = ลำดับ เริ่มที่ 1) มีเทิร์นซ้ำกันให้ keyset ต้องใช้ ID ตัดสิน
+func historyService(t *testing.T, turns []int) *service {
+	t.Helper()
+	store := repository.NewMemoryStore(32)
+	repo := repository.NewMemoryRepository(store)
+	for _, n := range turns {
+		res := &domain.BattleResult{Fighter1ID: "kid", Fighter2ID: "doc", Result: domain.ResultDraw, Turns: n}
+		if err := repo.Save(context.Background(), res); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &service{repo: repo}
+}
+
+// collect : เดินทีละหน้าจนหมด คืน ID ตามลำดับที่ได้ และจำนวนหน้า
+func collect(t *testing.T, s *service, sort domain.HistorySort, limit int) ([]uint, int) {
+	t.Helper()
+	var ids []uint
+	filter := domain.HistoryFilter{Sort: sort, Limit: limit}
+	for pages := 1; ; pages++ {
+		page, err := s.GetHistory(context.Background(), filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Battles) > limit && limit > 0 {
+			t.Fatalf("page %d has %d battles, limit %d", pages, len(page.Battles), limit)
+		}
+		for _, b := range page.Battles {
+			ids = append(ids, b.ID)
+		}
+		if page.NextCursor == "" {
+			return ids, pages
+		}
+		if pages > 100 {
+			t.Fatal("pagination does not terminate")
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+func TestHistoryPagination(t *testing.T) {
+	turns := []int{3, 5, 5, 1, 5, 2} // ID 1..6
+	want := map[domain.HistorySort][]uint{
+		domain.SortNewest:   {6, 5, 4, 3, 2, 1},
+		domain.SortOldest:   {1, 2, 3, 4, 5, 6},
+		domain.SortLongest:  {5, 3, 2, 1, 6, 4},
+		domain.SortShortest: {4, 6, 1, 2, 3, 5},
+	}
+	tests := []struct {
+		limit     int
+		wantPages int
+	}{
+		{1, 6},
+		{2, 3}, // หน้าสุดท้ายพอดี limit ต้องไม่มี cursor ชี้ไปหน้าว่าง
+		{4, 2},
+		{5, 2},
+		{6, 1}, // พอดีทั้งหมดในหน้าเดียว
+		{7, 1},
+		{0, 1}, // 0 = default
+	}
+	s := historyService(t, turns)
+	for sort, wantIDs := range want {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/limit=%d", sort, tt.limit), func(t *testing.T) {
+				ids, pages := collect(t, s, sort, tt.limit)
+				if !slices.Equal(ids, wantIDs) {
+					t.Fatalf("ids = %v, want %v", ids, wantIDs)
+				}
+				if pages != tt.wantPages {
+					t.Fatalf("pages = %d, want %d", pages, tt.wantPages)
+				}
+			})
+		}
+	}
+}
+
+func TestHistoryPaginationEmpty(t *testing.T) {
+	page, err := historyService(t, nil).GetHistory(context.Background(), domain.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Battles == nil || len(page.Battles) != 0 || page.NextCursor != "" {
+		t.Fatalf("empty history page = %+v", page)
+	}
+}
+
+func TestHistoryRejectsCursorFromAnotherSort(t *testing.T) {
+	s := historyService(t, []int{1, 2, 3})
+	page, err := s.GetHistory(context.Background(), domain.HistoryFilter{Sort: domain.SortLongest, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.GetHistory(context.Background(), domain.HistoryFilter{Sort: domain.SortNewest, Limit: 1, Cursor: page.NextCursor})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("err = %v, want ErrInvalidArgument", err)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/ports"
+	"context"
+)
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 100
+	defaultRatingHistory    = 50
+	maxRatingHistory        = 100
+)
+
+// ratingService : อ่านอย่างเดียว คะแนนถูกอัปเดตโดย BattleRepository ตอนบันทึก battle
+type ratingService struct {
+	repo ports.RatingRepository
+}
+
+func NewRatingService(r ports.RatingRepository) ports.RatingService {
+	return &ratingService{repo: r}
+}
+
+func (s *ratingService) Leaderboard(ctx context.Context, filter domain.LeaderboardFilter) (*domain.LeaderboardPage, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit > maxLeaderboardLimit {
+		limit = maxLeaderboardLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	// ขอเกินมา 1 ตัว เพื่อดูว่ายังมีหน้าถัดไปไหม
+	filter.Limit = limit + 1
+	ratings, err := s.repo.Leaderboard(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.LeaderboardPage{Ratings: ratings}
+	if len(ratings) > limit {
+		page.Ratings = ratings[:limit]
+		page.NextOffset = filter.Offset + limit
+	}
+	return page, nil
+}
+
+func (s *ratingService) GetRating(ctx context.Context, cowboyID string, limit int) (*domain.Rating, []domain.RatingChange, error) {
+	r, err := s.repo.FindRating(ctx, cowboyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r == nil {
+		return nil, nil, domain.ErrRatingNotFound
+	}
+
+	if limit <= 0 {
+		limit = defaultRatingHistory
+	}
+	if limit > maxRatingHistory {
+		limit = maxRatingHistory
+	}
+	history, err := s.repo.History(ctx, cowboyID, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, history, nil
+}
@@ -2,42 +2,220 @@ package services
 
 import (
 	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/domain/entity"
 	"api/services/arena/internal/core/ports"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultMatchupBudget = 2 * time.Second
+	maxMatchupBudget     = 10 * time.Second
+	defaultTurnPace      = 500 * time.Millisecond
+	maxTurnPace          = 5 * time.Second
+	defaultHistoryLimit  = 50
+	maxHistoryLimit      = 100
 )
 
 type service struct {
 	provider ports.CowboyProvider
 	repo     ports.BattleRepository
+	rules    domain.Rules
+}
+
+func NewArenaService(p ports.CowboyProvider, r ports.BattleRepository, rules domain.Rules) ports.ArenaService {
+	return &service{provider: p, repo: r, rules: rules}
+}
+
+// fighters : ดึงคู่ดวลใน round-trip เดียว (ห้ามดวลกับตัวเอง)
+func (s *service) fighters(ctx context.Context, id1, id2 string) (*entity.Cowboy, *entity.Cowboy, error) {
+	if id1 == "" || id2 == "" {
+		return nil, nil, fmt.Errorf("%w: both fighters are required", domain.ErrInvalidArgument)
+	}
+	if id1 == id2 {
+		return nil, nil, fmt.Errorf("%w: a cowboy cannot duel itself", domain.ErrInvalidArgument)
+	}
+	cowboys, err := s.provider.GetCowboys(ctx, []string{id1, id2})
+	if err != nil {
+		return nil, nil, err
+	}
+	return cowboys[0], cowboys[1], nil
+}
+
+func (s *service) GetHistory(ctx context.Context, filter domain.HistoryFilter) (*domain.HistoryPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if filter.Sort == "" {
+		filter.Sort = domain.SortNewest
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	after, err := decodeHistoryCursor(filter.Cursor, filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	// ขอเกินมา 1 ตัว เพื่อดูว่ายังมีหน้าถัดไปไหม
+	filter.Limit = limit + 1
+	battles, err := s.repo.GetHistory(ctx, filter, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.HistoryPage{Battles: battles}
+	if len(battles) > limit {
+		last := battles[limit-1]
+		page.Battles = battles[:limit]
+		page.NextCursor = encodeHistoryCursor(domain.HistoryCursor{Sort: filter.Sort, Turns: last.Turns, ID: last.ID})
+	}
+	if page.Battles == nil {
+		page.Battles = []domain.BattleResult{}
+	}
+	return page, nil
 }
 
-func NewArenaService(p ports.CowboyProvider, r ports.BattleRepository) ports.ArenaService {
-	return &service{provider: p, repo: r}
+// cursor = JSON ของตำแหน่งล่าสุด encode ไว้ไม่ให้ client ไปพึ่งรูปแบบข้างใน
+func encodeHistoryCursor(c domain.HistoryCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
 }
 
-func (s *service) GetHistory(limit int, fighterID string) ([]domain.BattleResult, error) {
-	return s.repo.GetHistory(limit, fighterID)
+func decodeHistoryCursor(cursor string, sort domain.HistorySort) (*domain.HistoryCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	var c domain.HistoryCursor
+	if err != nil || json.Unmarshal(data, &c) != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidArgument)
+	}
+	// cursor ผูกกับ sort ที่ใช้ตอนสร้าง เปลี่ยน sort กลางทางต้องเริ่มหน้าแรกใหม่
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", domain.ErrInvalidArgument, c.Sort)
+	}
+	return &c, nil
 }
 
-func (s *service) Duel(id1, id2 string) (*domain.BattleResult, error) {
-	// 1. เรียกข้อมูลจาก Port (Adapter จะไปเรียก gRPC)
-	c1, err := s.provider.GetCowboy(id1)
+func (s *service) GetBattle(ctx context.Context, id uint) (*domain.BattleResult, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *service) Duel(ctx context.Context, id1, id2 string) (*domain.BattleResult, error) {
+	// 1. เรียกข้อมูลจาก Port (Adapter จะไปเรียก gRPC ครั้งเดียวได้ทั้งคู่)
+	c1, c2, err := s.fighters(ctx, id1, id2)
 	if err != nil {
 		return nil, err
 	}
 
-	c2, err := s.provider.GetCowboy(id2)
+	// 2. รัน Domain Logic (สุ่ม seed ใหม่ทุกครั้ง แล้วเก็บไว้กับผลเพื่อ replay)
+	result := domain.SimulateFight(c1, c2, domain.NewSeed(), s.rules)
+
+	// 3. บันทึกผ่าน Port (Adapter จะไปลง DB พร้อมอัปเดตคะแนน)
+	if err := s.repo.Save(ctx, &result); err != nil {
+		return nil, errors.New("failed to save battle record")
+	}
+
+	return &result, nil
+}
+
+func (s *service) DuelLive(ctx context.Context, id1, id2 string, pace time.Duration, onEvent func(domain.BattleEvent)) (*domain.BattleResult, error) {
+	if pace < 0 {
+		pace = defaultTurnPace
+	}
+	if pace > maxTurnPace {
+		pace = maxTurnPace
+	}
+
+	c1, c2, err := s.fighters(ctx, id1, id2)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. รัน Domain Logic
-	result := domain.SimulateFight(c1, c2)
+	// เว้นจังหวะก่อนเริ่มแต่ละเทิร์น (client ตัดสายแล้วก็ไม่ต้องรอ ดวลให้จบเลย)
+	observe := func(e domain.BattleEvent) {
+		if e.Type == domain.EventTurnStart && pace > 0 {
+			timer := time.NewTimer(pace)
+			select {
+			case <-ctx.Done():
+			case <-timer.C:
+			}
+			timer.Stop()
+		}
+		onEvent(e)
+	}
+	result := domain.SimulateFightLive(c1, c2, domain.NewSeed(), s.rules, observe)
 
-	// 3. บันทึกผ่าน Port (Adapter จะไปลง DB)
-	if err := s.repo.Save(&result, id1, id2); err != nil {
+	// ผลถูกตัดสินไปแล้ว บันทึกแม้ผู้ชมจะออกไปก่อนจบ
+	saveCtx := context.WithoutCancel(ctx)
+	if err := s.repo.Save(saveCtx, &result); err != nil {
 		return nil, errors.New("failed to save battle record")
 	}
 
 	return &result, nil
 }
+
+func (s *service) Series(ctx context.Context, id1, id2 string, bestOf int) (*domain.Series, error) {
+	if err := domain.ValidateBestOf(bestOf); err != nil {
+		return nil, err
+	}
+
+	c1, c2, err := s.fighters(ctx, id1, id2)
+	if err != nil {
+		return nil, err
+	}
+
+	// ทุกเกมใช้สำเนาใหม่ของ c1 / c2 (Domain จัดการให้)
+	series := domain.PlaySeries(c1, c2, bestOf, domain.NewSeed(), s.rules)
+
+	if err := s.repo.SaveSeries(ctx, &series); err != nil {
+		return nil, errors.New("failed to save series record")
+	}
+
+	return &series, nil
+}
+
+func (s *service) GetSeries(ctx context.Context, id uint) (*domain.Series, error) {
+	return s.repo.FindSeries(ctx, id)
+}
+
+func (s *service) GetFighterStats(ctx context.Context, cowboyID string) (*domain.FighterStats, error) {
+	return s.repo.FighterStats(ctx, cowboyID)
+}
+
+func (s *service) Matchup(ctx context.Context, id1, id2 string, samples int, budget time.Duration) (*domain.Matchup, error) {
+	if samples == 0 {
+		samples = domain.DefaultMatchupSamples
+	}
+	if err := domain.ValidateSamples(samples); err != nil {
+		return nil, err
+	}
+	if budget <= 0 {
+		budget = defaultMatchupBudget
+	}
+	if budget > maxMatchupBudget {
+		budget = maxMatchupBudget
+	}
+
+	c1, c2, err := s.fighters(ctx, id1, id2)
+	if err != nil {
+		return nil, err
+	}
+
+	// จับเวลาหลังดึงข้อมูลเสร็จ budget จะได้เป็นเวลาจำลองล้วนๆ
+	// client ตัดสายก่อนก็หยุดจำลองทันที (ctx ของ request ถูกยกเลิก)
+	simCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+	m := domain.EstimateMatchup(simCtx, c1, c2, samples, domain.NewSeed(), s.rules)
+	return &m, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"api/services/arena/internal/core/domain"
+	"api/services/arena/internal/core/domain/entity"
+	"api/services/arena/internal/core/ports"
+	"context"
+	"errors"
+)
+
+type tournamentService struct {
+	provider ports.CowboyProvider
+	repo     ports.TournamentRepository
+	rules    domain.Rules
+}
+
+func NewTournamentService(p ports.CowboyProvider, r ports.TournamentRepository, rules domain.Rules) ports.TournamentService {
+	return &tournamentService{provider: p, repo: r, rules: rules}
+}
+
+func (s *tournamentService) Create(ctx context.Context, name string, format domain.TournamentFormat, seeding domain.Seeding, cowboyIDs []string) (*domain.Tournament, error) {
+	// 1. ดึง Cowboy ทุกตัวในครั้งเดียว (เช็คว่ามีอยู่จริงและเอาชื่อมาเก็บ)
+	cowboys, err := s.provider.GetCowboys(ctx, cowboyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. ให้ Domain จัดสาย
+	t, err := domain.NewTournament(name, format, seeding, cowboys, domain.NewSeed())
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. บันทึก
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, errors.New("failed to save tournament")
+	}
+	return t, nil
+}
+
+func (s *tournamentService) Get(ctx context.Context, id uint) (*domain.Tournament, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *tournamentService) Advance(ctx context.Context, id uint) (*domain.Tournament, error) {
+	t, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.playRound(ctx, t, 0)
+}
+
+func (s *tournamentService) ReplayRound(ctx context.Context, id uint, round int) (*domain.Tournament, error) {
+	t, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.ResetRound(round); err != nil {
+		return nil, err
+	}
+	return s.playRound(ctx, t, round)
+}
+
+// playRound : ดึงค่าพลังปัจจุบันของทุกคน แล้วดวลรอบถัดไปและบันทึก (replayFrom ดู TournamentRepository.SaveRound)
+func (s *tournamentService) playRound(ctx context.Context, t *domain.Tournament, replayFrom int) (*domain.Tournament, error) {
+	list, err := s.provider.GetCowboys(ctx, t.CowboyIDs())
+	if err != nil {
+		return nil, err
+	}
+	cowboys := make(map[string]*entity.Cowboy, len(list))
+	for _, c := range list {
+		cowboys[c.ID] = c
+	}
+
+	battles, err := t.PlayRound(cowboys, s.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveRound(ctx, t, battles, replayFrom); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, err
+		}
+		return nil, errors.New("failed to save tournament round")
+	}
+	return t, nil
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os" // ยังต้องใช้ os เพื่อ override ชื่อ ENV เฉพาะของ service นี้
+	"os/signal"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -12,8 +18,10 @@ import (
 	"api/pkg/config" // ✅ เรียกใช้ Config Package
 	"api/pkg/database"
 	pb "api/proto"
+	"api/services/duelist/internal/adapters/broker"
 	"api/services/duelist/internal/adapters/handler"
 	"api/services/duelist/internal/adapters/repository"
+	"api/services/duelist/internal/core/ports"
 	"api/services/duelist/internal/core/services"
 )
 
@@ -26,18 +34,79 @@ func main() {
 	if p := os.Getenv("DUELIST_PORT"); p != "" {
 		cfg.AppPort = p
 	}
+	// REST facade ใช้อีก port (ไม่ตั้ง = 8082)
+	httpPort := os.Getenv("DUELIST_HTTP_PORT")
+	if httpPort == "" {
+		httpPort = "8082"
+	}
+	// DB แยกต่อ service ได้ (ไม่ตั้ง = ใช้ DB_DSN กลาง)
+	if dsn := os.Getenv("DUELIST_DB_DSN"); dsn != "" {
+		cfg.DBUrl = dsn
+	}
 
-	// 2. Initialize Infrastructure (DB Singleton)
-	// ใช้ค่าจาก cfg แทน os.Getenv
-	db, err := database.GetInstance(cfg.DBUrl)
+	// ctx จบเมื่อได้ SIGINT / SIGTERM (ใช้ทั้งตอนรอ DB และตอน shutdown)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 2. Initialize Infrastructure (เลือก DB จาก scheme ของ DB_DSN)
+	driver, _, err := database.ParseDSN(cfg.DBUrl)
 	if err != nil {
-		log.Fatalf("❌ Failed to initialize database: %v", err)
+		log.Fatalf("❌ Invalid DB_DSN: %v", err)
+	}
+	// subcommand (migrate / rollback / status) จัดการ schema แล้วจบเลย ไม่ start server
+	command := os.Args[1:]
+	if len(command) > 0 && driver == database.DriverMemory {
+		log.Fatalf("❌ %s has no schema to migrate", cfg.DBUrl)
+	}
+
+	var (
+		db          *database.DB // nil = in-memory
+		repoAdapter ports.CowboyRepository
+	)
+	if driver == database.DriverMemory {
+		log.Println("🧠 Using in-memory repository (data is lost on exit)")
+		repoAdapter = repository.NewMemoryRepository()
+	} else {
+		db, err = database.Open(ctx, database.Options{
+			DSN:             cfg.DBUrl,
+			MaxOpenConns:    cfg.DBMaxOpen,
+			MaxIdleConns:    cfg.DBMaxIdle,
+			ConnMaxLifetime: time.Duration(cfg.DBMaxLifetime) * time.Second,
+			ConnectRetries:  cfg.DBRetries,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize database: %v", err)
+		}
+		migrator, err := database.NewMigrator(db.Primary(), "duelist", repository.Migrations())
+		if err != nil {
+			log.Fatalf("❌ Invalid migrations: %v", err)
+		}
+		if len(command) > 0 {
+			err := database.RunMigrationCommand(ctx, migrator, command, os.Stdout)
+			db.Close()
+			if err != nil {
+				log.Fatalf("❌ %v", err)
+			}
+			return
+		}
+		if cfg.AutoMigrate {
+			if _, err := migrator.Up(ctx); err != nil {
+				log.Fatalf("❌ Failed to migrate database: %v", err)
+			}
+		}
+		// ไม่ยอม start ถ้า schema ไม่ตรงกับโค้ด
+		if err := migrator.EnsureCurrent(ctx); err != nil {
+			log.Fatalf("❌ %v (run `duelist migrate` first)", err)
+		}
+		// gorm repository ใช้ได้ทั้ง MySQL และ SQLite
+		repoAdapter = repository.NewMySQLRepository(db.Primary())
 	}
 
 	// 3. Setup Layers (เหมือนเดิม)
-	repoAdapter := repository.NewMySQLRepository(db)
-	svc := services.NewDuelistService(repoAdapter)
+	eventBroker := broker.NewMemoryBroker()
+	svc := services.NewDuelistService(repoAdapter, eventBroker)
 	grpcHandler := handler.NewGrpcHandler(svc)
+	httpHandler := handler.NewHttpHandler(svc)
 
 	// 4. Start Server (ใช้ Port จาก cfg)
 	lis, err := net.Listen("tcp", ":"+cfg.AppPort)
@@ -45,11 +114,59 @@ func main() {
 		log.Fatalf("❌ Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	// interceptor แนบชื่อผู้สั่งเปลี่ยน (x-actor) ไว้บันทึกลงประวัติ version
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(handler.ActorInterceptor))
 	pb.RegisterDuelistServiceServer(grpcServer, grpcHandler)
 
-	fmt.Printf("🤠 Duelist Service running on port :%s\n", cfg.AppPort)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("❌ Failed to serve: %v", err)
+	// 5. Start REST facade (สำหรับ admin tools / frontend ที่ไม่ได้ใช้ gRPC)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cowboys", httpHandler.HandleCowboys)
+	mux.HandleFunc("/cowboys/{id}", httpHandler.HandleCowboy)
+	mux.HandleFunc("/cowboys/{id}/versions", httpHandler.HandleCowboyVersions)
+	httpServer := &http.Server{Addr: ":" + httpPort, Handler: handler.ActorMiddleware(mux)}
+	go func() {
+		fmt.Printf("🤠 Duelist REST running on port :%s\n", httpPort)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("❌ Server failed to start: %v", err)
+		}
+	}()
+
+	go func() {
+		fmt.Printf("🤠 Duelist Service running on port :%s\n", cfg.AppPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("❌ Failed to serve: %v", err)
+		}
+	}()
+
+	// 6. รอสัญญาณปิด แล้วปิด server ก่อนค่อยปิด DB (request ที่ค้างอยู่จะได้จบก่อน)
+	<-ctx.Done()
+	log.Println("🛑 Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️  REST shutdown: %v", err)
+	}
+	stopGRPC(shutdownCtx, grpcServer)
+	if db != nil {
+		if err := db.Close(); err != nil {
+			log.Printf("⚠️  Database close: %v", err)
+		}
+	}
+}
+
+// shutdownTimeout : เวลาที่ให้ request ที่ค้างอยู่ทำต่อจนจบตอนปิด service
+const shutdownTimeout = 10 * time.Second
+
+// stopGRPC : รอ RPC ที่ค้างอยู่ให้จบ แต่ stream ยาวๆ (เช่น WatchCowboys) ไม่มีวันจบเอง จึงตัดเมื่อหมดเวลา
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
 	}
 }
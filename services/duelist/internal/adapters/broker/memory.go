@@ -0,0 +1,56 @@
+package broker
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"api/services/duelist/internal/core/ports"
+	"context"
+	"sync"
+)
+
+// subscriberBuffer : จำนวน event ที่ค้างได้ต่อผู้ฟัง ก่อนจะถือว่าตามไม่ทัน
+const subscriberBuffer = 64
+
+type memoryBroker struct {
+	mu   sync.Mutex
+	subs map[chan domain.CowboyEvent]struct{}
+}
+
+// NewMemoryBroker : broker ภายใน process (ใช้ได้กับ Duelist instance เดียว)
+func NewMemoryBroker() ports.CowboyEventBroker {
+	return &memoryBroker{subs: make(map[chan domain.CowboyEvent]struct{})}
+}
+
+// Publish : ไม่ block ผู้เรียก ใครรับไม่ทันจะถูกตัดออก (channel ถูกปิด)
+func (b *memoryBroker) Publish(event domain.CowboyEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context) <-chan domain.CowboyEvent {
+	ch := make(chan domain.CowboyEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	// ctx จบ = ถอนตัว (ถ้ายังไม่ถูกตัดไปก่อน)
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[ch]; ok {
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}()
+	return ch
+}
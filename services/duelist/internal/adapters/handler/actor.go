@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ชื่อ header / metadata ที่บอกว่าใครเป็นคนสั่งเปลี่ยน (บันทึกลงประวัติ version)
+const (
+	actorMetadataKey = "x-actor"
+	actorHeader      = "X-Actor"
+)
+
+// ActorInterceptor : อ่าน x-actor จาก gRPC metadata แล้วแนบไปกับ ctx
+func ActorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(actorMetadataKey); len(v) > 0 {
+			ctx = domain.WithActor(ctx, v[0])
+		}
+	}
+	return next(ctx, req)
+}
+
+// ActorMiddleware : แบบเดียวกับ ActorInterceptor แต่อ่านจาก HTTP header X-Actor
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(actorHeader); actor != "" {
+			r = r.WithContext(domain.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestActorFromRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor string // ว่าง = ไม่ส่งมา
+		want  string
+	}{
+		{"sent", "alice", "alice"},
+		{"not sent", "", domain.UnknownActor},
+	}
+	for _, tt := range tests {
+		t.Run("grpc/"+tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.actor != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(actorMetadataKey, tt.actor))
+			}
+			var got string
+			ActorInterceptor(ctx, nil, nil, func(ctx context.Context, req any) (any, error) {
+				got = domain.ActorFrom(ctx)
+				return nil, nil
+			})
+			if got != tt.want {
+				t.Fatalf("actor = %q, want %q", got, tt.want)
+			}
+		})
+		t.Run("http/"+tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PATCH", "/cowboys/kid", nil)
+			if tt.actor != "" {
+				req.Header.Set(actorHeader, tt.actor)
+			}
+			var got string
+			ActorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = domain.ActorFrom(r.Context())
+			})).ServeHTTP(httptest.NewRecorder(), req)
+			if got != tt.want {
+				t.Fatalf("actor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus : แปลง Domain Error จาก core ให้เป็น gRPC status ที่ client เอาไปใช้ต่อได้
+func toStatus(err error) error {
+	var ve *domain.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		return validationStatus(ve)
+	case errors.Is(err, domain.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrCowboyNotFound), errors.Is(err, domain.ErrVersionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrCowboyAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// validationStatus : InvalidArgument พร้อมรายละเอียดราย field (BadRequest)
+func validationStatus(ve *domain.ValidationError) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range ve.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st, err := status.New(codes.InvalidArgument, ve.Error()).WithDetails(br)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, ve.Error())
+	}
+	return st.Err()
+}
+
+// errorResponse : รูปแบบ JSON ของ error ฝั่ง REST (Details มีเฉพาะตอน validate ไม่ผ่าน)
+type errorResponse struct {
+	Error   string           `json:"error"`
+	Details []fieldViolation `json:"details,omitempty"`
+}
+
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, errorResponse{Error: msg})
+}
+
+// writeServiceError : คู่กับ toStatus แต่เป็น HTTP status (ที่ไม่รู้จัก = 500)
+func writeServiceError(w http.ResponseWriter, err error) {
+	var ve *domain.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		resp := errorResponse{Error: ve.Error()}
+		for _, v := range ve.Violations {
+			resp.Details = append(resp.Details, fieldViolation{Field: v.Field, Description: v.Description})
+		}
+		writeJSON(w, http.StatusBadRequest, resp)
+	case errors.Is(err, domain.ErrInvalidArgument):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrCowboyNotFound), errors.Is(err, domain.ErrVersionNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrCowboyAlreadyExists), errors.Is(err, domain.ErrVersionConflict):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
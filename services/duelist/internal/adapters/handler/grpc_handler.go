@@ -5,6 +5,10 @@ import (
 	"api/services/duelist/internal/core/domain"
 	"api/services/duelist/internal/core/ports"
 	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GrpcHandler struct {
@@ -26,22 +30,153 @@ func (h *GrpcHandler) CreateCowboy(ctx context.Context, req *pb.CreateCowboyRequ
 		Accuracy: req.Accuracy,
 	}
 
-	created, err := h.service.Create(domainCowboy)
+	created, err := h.service.Create(ctx, domainCowboy)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return h.toProto(created), nil
 }
 
 func (h *GrpcHandler) GetCowboy(ctx context.Context, req *pb.GetCowboyRequest) (*pb.CowboyResponse, error) {
-	cowboy, err := h.service.Get(req.Id)
+	var cowboy *domain.Cowboy
+	var err error
+	switch at := req.At.(type) {
+	case *pb.GetCowboyRequest_Version:
+		cowboy, err = h.service.GetVersion(ctx, req.Id, int(at.Version))
+	case *pb.GetCowboyRequest_AsOf:
+		cowboy, err = h.service.GetAt(ctx, req.Id, at.AsOf.AsTime())
+	default:
+		cowboy, err = h.service.Get(ctx, req.Id)
+	}
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return h.toProto(cowboy), nil
 }
 
+func (h *GrpcHandler) BatchGetCowboys(ctx context.Context, req *pb.BatchGetCowboysRequest) (*pb.BatchGetCowboysResponse, error) {
+	batch, err := h.service.BatchGet(ctx, req.Ids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.BatchGetCowboysResponse{MissingIds: batch.MissingIDs}
+	for _, c := range batch.Cowboys {
+		resp.Cowboys = append(resp.Cowboys, h.toProto(c))
+	}
+	return resp, nil
+}
+
+func (h *GrpcHandler) UpdateCowboy(ctx context.Context, req *pb.UpdateCowboyRequest) (*pb.CowboyResponse, error) {
+	patch := &domain.Cowboy{
+		ID:       req.Id,
+		Name:     req.Name,
+		Health:   int(req.Health),
+		Damage:   int(req.Damage),
+		Speed:    int(req.Speed),
+		Accuracy: req.Accuracy,
+	}
+
+	updated, err := h.service.Update(ctx, patch, req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return h.toProto(updated), nil
+}
+
+func (h *GrpcHandler) DeleteCowboy(ctx context.Context, req *pb.DeleteCowboyRequest) (*pb.DeleteCowboyResponse, error) {
+	if err := h.service.Delete(ctx, req.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteCowboyResponse{}, nil
+}
+
+func (h *GrpcHandler) ListCowboys(ctx context.Context, req *pb.ListCowboysRequest) (*pb.ListCowboysResponse, error) {
+	page, err := h.service.List(ctx, domain.CowboyFilter{
+		NameContains: req.NameFilter,
+		PageSize:     int(req.PageSize),
+		PageToken:    req.PageToken,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListCowboysResponse{NextPageToken: page.NextPageToken}
+	for _, c := range page.Cowboys {
+		resp.Cowboys = append(resp.Cowboys, h.toProto(c))
+	}
+	return resp, nil
+}
+
+// WatchCowboys : ส่ง event ไปเรื่อยๆ จนกว่า client จะตัด หรือตามไม่ทัน
+func (h *GrpcHandler) WatchCowboys(req *pb.WatchCowboysRequest, stream pb.DuelistService_WatchCowboysServer) error {
+	ctx := stream.Context()
+	events := h.service.Watch(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Error(codes.Unavailable, "watcher fell behind, reconnect and resync")
+			}
+			if err := stream.Send(h.eventToProto(ev)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *GrpcHandler) ListCowboyVersions(ctx context.Context, req *pb.ListCowboyVersionsRequest) (*pb.ListCowboyVersionsResponse, error) {
+	page, err := h.service.ListVersions(ctx, domain.CowboyVersionFilter{
+		ID:        req.Id,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListCowboyVersionsResponse{NextPageToken: page.NextPageToken}
+	for _, v := range page.Versions {
+		out := &pb.CowboyVersion{
+			Change:    eventTypeToProto(v.Change),
+			Cowboy:    h.toProto(&v.Cowboy),
+			ChangedBy: v.ChangedBy,
+			ChangedAt: timestamppb.New(v.ChangedAt),
+		}
+		if v.Previous != nil {
+			out.Previous = h.toProto(v.Previous)
+		}
+		resp.Versions = append(resp.Versions, out)
+	}
+	return resp, nil
+}
+
+func eventTypeToProto(t domain.CowboyEventType) pb.CowboyEvent_Type {
+	switch t {
+	case domain.CowboyCreated:
+		return pb.CowboyEvent_CREATED
+	case domain.CowboyUpdated:
+		return pb.CowboyEvent_UPDATED
+	case domain.CowboyDeleted:
+		return pb.CowboyEvent_DELETED
+	}
+	return pb.CowboyEvent_TYPE_UNSPECIFIED
+}
+
+func (h *GrpcHandler) eventToProto(ev domain.CowboyEvent) *pb.CowboyEvent {
+	out := &pb.CowboyEvent{Id: ev.ID, Type: eventTypeToProto(ev.Type)}
+	if ev.Cowboy != nil {
+		out.Cowboy = h.toProto(ev.Cowboy)
+	}
+	return out
+}
+
 func (h *GrpcHandler) toProto(c *domain.Cowboy) *pb.CowboyResponse {
 	return &pb.CowboyResponse{
 		Id:       c.ID,
@@ -50,5 +185,6 @@ func (h *GrpcHandler) toProto(c *domain.Cowboy) *pb.CowboyResponse {
 		Damage:   int32(c.Damage),
 		Speed:    int32(c.Speed),
 		Accuracy: c.Accuracy,
+		Version:  int32(c.Version),
 	}
 }
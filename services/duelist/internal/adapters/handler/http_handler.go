@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"api/services/duelist/internal/core/ports"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HttpHandler : REST facade ของ DuelistService (ใช้ port ตัวเดียวกับ GrpcHandler)
+type HttpHandler struct {
+	service ports.DuelistService
+}
+
+func NewHttpHandler(service ports.DuelistService) *HttpHandler {
+	return &HttpHandler{service: service}
+}
+
+// cowboyJSON : ชื่อ field ตรงกับ CowboyResponse ใน duelist.proto
+type cowboyJSON struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Health   int     `json:"health"`
+	Damage   int     `json:"damage"`
+	Speed    int     `json:"speed"`
+	Accuracy float64 `json:"accuracy"`
+	Version  int     `json:"version,omitempty"` // server เป็นคนกำหนด ค่าที่ส่งมาจะถูกข้าม
+}
+
+// versionJSON : ตรงกับ CowboyVersion ใน duelist.proto
+type versionJSON struct {
+	Change    string      `json:"change"`
+	Cowboy    cowboyJSON  `json:"cowboy"`
+	Previous  *cowboyJSON `json:"previous,omitempty"`
+	ChangedBy string      `json:"changed_by"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+type listVersionsResponse struct {
+	Versions      []versionJSON `json:"versions"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+type listCowboysResponse struct {
+	Cowboys       []cowboyJSON `json:"cowboys"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+func toJSON(c *domain.Cowboy) cowboyJSON {
+	return cowboyJSON{
+		ID:       c.ID,
+		Name:     c.Name,
+		Health:   c.Health,
+		Damage:   c.Damage,
+		Speed:    c.Speed,
+		Accuracy: c.Accuracy,
+		Version:  c.Version,
+	}
+}
+
+func (c cowboyJSON) toDomain() *domain.Cowboy {
+	return &domain.Cowboy{
+		ID:       c.ID,
+		Name:     c.Name,
+		Health:   c.Health,
+		Damage:   c.Damage,
+		Speed:    c.Speed,
+		Accuracy: c.Accuracy,
+	}
+}
+
+// HandleCowboys : /cowboys (POST = สร้าง, GET = รายชื่อทีละหน้า)
+func (h *HttpHandler) HandleCowboys(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		h.create(w, r)
+	case "GET":
+		h.list(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleCowboy : /cowboys/{id} (GET, PATCH = แก้เฉพาะ field ที่ส่งมา, DELETE)
+func (h *HttpHandler) HandleCowboy(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		h.get(w, r)
+	case "PATCH":
+		h.update(w, r)
+	case "DELETE":
+		h.delete(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *HttpHandler) create(w http.ResponseWriter, r *http.Request) {
+	var req cowboyJSON
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	created, err := h.service.Create(r.Context(), req.toDomain())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toJSON(created))
+}
+
+func (h *HttpHandler) list(w http.ResponseWriter, r *http.Request) {
+	// อ่าน query: page_size, page_token, name_filter (เหมือน ListCowboysRequest)
+	query := r.URL.Query()
+	size := 0
+	if v := query.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "page_size must be a non-negative integer")
+			return
+		}
+		size = n
+	}
+
+	page, err := h.service.List(r.Context(), domain.CowboyFilter{
+		NameContains: query.Get("name_filter"),
+		PageSize:     size,
+		PageToken:    query.Get("page_token"),
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	resp := listCowboysResponse{
+		Cowboys:       make([]cowboyJSON, 0, len(page.Cowboys)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, c := range page.Cowboys {
+		resp.Cowboys = append(resp.Cowboys, toJSON(c))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *HttpHandler) get(w http.ResponseWriter, r *http.Request) {
+	// ?version=N หรือ ?as_of=RFC3339 = ดูค่าย้อนหลัง (เหมือน oneof at ใน GetCowboyRequest)
+	id := r.PathValue("id")
+	query := r.URL.Query()
+	version, asOf := query.Get("version"), query.Get("as_of")
+
+	var cowboy *domain.Cowboy
+	var err error
+	switch {
+	case version != "" && asOf != "":
+		writeError(w, http.StatusBadRequest, "version and as_of cannot be used together")
+		return
+	case version != "":
+		n, convErr := strconv.Atoi(version)
+		if convErr != nil {
+			writeError(w, http.StatusBadRequest, "version must be an integer")
+			return
+		}
+		cowboy, err = h.service.GetVersion(r.Context(), id, n)
+	case asOf != "":
+		at, parseErr := time.Parse(time.RFC3339, asOf)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, "as_of must be an RFC3339 timestamp")
+			return
+		}
+		cowboy, err = h.service.GetAt(r.Context(), id, at)
+	default:
+		cowboy, err = h.service.Get(r.Context(), id)
+	}
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toJSON(cowboy))
+}
+
+func (h *HttpHandler) update(w http.ResponseWriter, r *http.Request) {
+	// อ่าน body 2 รอบ: รอบแรกเอาชื่อ key ที่ส่งมาไปทำ mask, รอบสองเอาค่า
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid body")
+		return
+	}
+	var raw map[string]json.RawMessage
+	var req cowboyJSON
+	if json.Unmarshal(body, &raw) != nil || json.Unmarshal(body, &req) != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	fields := make([]string, 0, len(raw))
+	for key := range raw {
+		if key != "id" && key != "version" { // id มาจาก path, version ขยับเอง
+			fields = append(fields, key)
+		}
+	}
+	if len(fields) == 0 {
+		writeError(w, http.StatusBadRequest, "no fields to update")
+		return
+	}
+
+	patch := req.toDomain()
+	patch.ID = r.PathValue("id")
+	updated, err := h.service.Update(r.Context(), patch, fields)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toJSON(updated))
+}
+
+func (h *HttpHandler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleCowboyVersions : GET /cowboys/{id}/versions (ประวัติการเปลี่ยนแปลง ทีละหน้า)
+func (h *HttpHandler) HandleCowboyVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	size := 0
+	if v := query.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "page_size must be a non-negative integer")
+			return
+		}
+		size = n
+	}
+
+	page, err := h.service.ListVersions(r.Context(), domain.CowboyVersionFilter{
+		ID:        r.PathValue("id"),
+		PageSize:  size,
+		PageToken: query.Get("page_token"),
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	resp := listVersionsResponse{
+		Versions:      make([]versionJSON, 0, len(page.Versions)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, v := range page.Versions {
+		out := versionJSON{
+			Change:    string(v.Change),
+			Cowboy:    toJSON(&v.Cowboy),
+			ChangedBy: v.ChangedBy,
+			ChangedAt: v.ChangedAt,
+		}
+		if v.Previous != nil {
+			prev := toJSON(v.Previous)
+			out.Previous = &prev
+		}
+		resp.Versions = append(resp.Versions, out)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"api/services/duelist/internal/core/ports"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryRepo : เก็บทุกอย่างไว้ในหน่วยความจำ (สำหรับ dev / test ปิดโปรแกรมแล้วหาย)
+// พฤติกรรมเหมือน mysqlRepo: soft delete, ID ซ้ำกับตัวที่ลบไปแล้วก็ยังถือว่าซ้ำ
+type memoryRepo struct {
+	mu       sync.RWMutex
+	cowboys  map[string]*domain.Cowboy
+	deleted  map[string]bool
+	versions map[string][]*domain.CowboyVersion // เรียงตาม version
+}
+
+func NewMemoryRepository() ports.CowboyRepository {
+	return &memoryRepo{
+		cowboys:  make(map[string]*domain.Cowboy),
+		deleted:  make(map[string]bool),
+		versions: make(map[string][]*domain.CowboyVersion),
+	}
+}
+
+func (r *memoryRepo) Save(ctx context.Context, v *domain.CowboyVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cowboys[v.Cowboy.ID]; ok {
+		return domain.ErrCowboyAlreadyExists
+	}
+	c := v.Cowboy
+	r.cowboys[c.ID] = &c
+	r.appendVersion(v)
+	return nil
+}
+
+func (r *memoryRepo) FindByID(ctx context.Context, id string) (*domain.Cowboy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.cowboys[id]
+	if !ok || r.deleted[id] {
+		return nil, domain.ErrCowboyNotFound
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *memoryRepo) FindByIDs(ctx context.Context, ids []string) ([]*domain.Cowboy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cowboys := make([]*domain.Cowboy, 0, len(ids))
+	for _, id := range ids {
+		if c, ok := r.cowboys[id]; ok && !r.deleted[id] {
+			cp := *c
+			cowboys = append(cowboys, &cp)
+		}
+	}
+	return cowboys, nil
+}
+
+func (r *memoryRepo) Update(ctx context.Context, v *domain.CowboyVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.bumpVersion(&v.Cowboy); err != nil {
+		return err
+	}
+	r.appendVersion(v)
+	return nil
+}
+
+func (r *memoryRepo) Delete(ctx context.Context, v *domain.CowboyVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.bumpVersion(&v.Cowboy); err != nil {
+		return err
+	}
+	r.deleted[v.Cowboy.ID] = true
+	r.appendVersion(v)
+	return nil
+}
+
+// bumpVersion : เหมือนฝั่ง mysql (ต้องถือ lock อยู่แล้ว)
+func (r *memoryRepo) bumpVersion(cowboy *domain.Cowboy) error {
+	current, ok := r.cowboys[cowboy.ID]
+	if !ok || r.deleted[cowboy.ID] {
+		return domain.ErrCowboyNotFound
+	}
+	if current.Version != cowboy.Version-1 {
+		return domain.ErrVersionConflict
+	}
+	c := *cowboy
+	r.cowboys[c.ID] = &c
+	return nil
+}
+
+// appendVersion : เก็บสำเนา ผู้เรียกแก้ v ต่อได้โดยไม่กระทบประวัติ
+func (r *memoryRepo) appendVersion(v *domain.CowboyVersion) {
+	r.versions[v.Cowboy.ID] = append(r.versions[v.Cowboy.ID], copyVersion(v))
+}
+
+func copyVersion(v *domain.CowboyVersion) *domain.CowboyVersion {
+	cp := *v
+	if v.Previous != nil {
+		prev := *v.Previous
+		cp.Previous = &prev
+	}
+	return &cp
+}
+
+func (r *memoryRepo) List(ctx context.Context, nameContains, afterID string, limit int) ([]*domain.Cowboy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// LIKE ของ MySQL ไม่สนตัวพิมพ์เล็ก/ใหญ่ (collation ปกติ) ทำให้เหมือนกัน
+	needle := strings.ToLower(nameContains)
+	var cowboys []*domain.Cowboy
+	for id, c := range r.cowboys {
+		if r.deleted[id] || id <= afterID || !strings.Contains(strings.ToLower(c.Name), needle) {
+			continue
+		}
+		cp := *c
+		cowboys = append(cowboys, &cp)
+	}
+	sort.Slice(cowboys, func(i, j int) bool { return cowboys[i].ID < cowboys[j].ID })
+	if len(cowboys) > limit {
+		cowboys = cowboys[:limit]
+	}
+	return cowboys, nil
+}
+
+func (r *memoryRepo) FindVersion(ctx context.Context, id string, version int) (*domain.CowboyVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, v := range r.versions[id] {
+		if v.Cowboy.Version == version {
+			return copyVersion(v), nil
+		}
+	}
+	return nil, domain.ErrVersionNotFound
+}
+
+func (r *memoryRepo) FindVersionAt(ctx context.Context, id string, at time.Time) (*domain.CowboyVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *domain.CowboyVersion
+	for _, v := range r.versions[id] {
+		if !v.ChangedAt.After(at) {
+			found = v
+		}
+	}
+	if found == nil {
+		return nil, domain.ErrVersionNotFound
+	}
+	return copyVersion(found), nil
+}
+
+func (r *memoryRepo) ListVersions(ctx context.Context, id string, afterVersion, limit int) ([]*domain.CowboyVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]*domain.CowboyVersion, 0, limit)
+	for _, v := range r.versions[id] {
+		if len(versions) == limit {
+			break
+		}
+		if v.Cowboy.Version > afterVersion {
+			versions = append(versions, copyVersion(v))
+		}
+	}
+	return versions, nil
+}
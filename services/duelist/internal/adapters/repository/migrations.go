@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"api/pkg/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migrations : schema ของ Duelist เรียงตาม version (เพิ่มต่อท้ายเท่านั้น ห้ามแก้ตัวที่ปล่อยไปแล้ว)
+// struct ในแต่ละ migration คือหน้าตาตารางณ ตอนนั้น แยกจาก model ที่ใช้งานจริง
+// DB เดิมที่เคยสร้างด้วย AutoMigrate จะข้ามส่วนที่มีอยู่แล้ว แล้วเดินต่อจนครบทุกขั้น
+func Migrations() []database.Migration {
+	return []database.Migration{
+		{
+			// baseline: ตารางแบบเดิมก่อนมี migration
+			Version: 1,
+			Name:    "create_cowboys",
+			Up: func(tx *gorm.DB) error {
+				return database.CreateTable(tx, &cowboyV1{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("cowboys")
+			},
+		},
+		{
+			Version: 2,
+			Name:    "add_cowboy_soft_delete",
+			Up: func(tx *gorm.DB) error {
+				if err := database.AddColumns(tx, &cowboyV2{}, "deleted_at"); err != nil {
+					return err
+				}
+				return database.CreateIndexes(tx, &cowboyV2{}, "idx_cowboys_deleted_at")
+			},
+			Down: func(tx *gorm.DB) error {
+				// ตัวที่ถูกลบไปแล้วต้องหายจริง ไม่งั้นย้อนแล้วจะกลับมามีชีวิต
+				if err := tx.Exec("DELETE FROM cowboys WHERE deleted_at IS NOT NULL").Error; err != nil {
+					return err
+				}
+				if err := database.DropIndexes(tx, &cowboyV2{}, "idx_cowboys_deleted_at"); err != nil {
+					return err
+				}
+				return database.DropColumns(tx, &cowboyV2{}, "deleted_at")
+			},
+		},
+		{
+			// ตัวที่มีอยู่แล้วได้ version 1 จากค่า default
+			Version: 3,
+			Name:    "add_cowboy_version",
+			Up: func(tx *gorm.DB) error {
+				return database.AddColumns(tx, &cowboyV3{}, "version")
+			},
+			Down: func(tx *gorm.DB) error {
+				return database.DropColumns(tx, &cowboyV3{}, "version")
+			},
+		},
+		{
+			Version: 4,
+			Name:    "create_cowboy_versions",
+			Up: func(tx *gorm.DB) error {
+				return database.CreateTable(tx, &cowboyVersionV4{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("cowboy_versions")
+			},
+		},
+	}
+}
+
+type cowboyV1 struct {
+	ID       string `gorm:"primaryKey"`
+	Name     string
+	Health   int
+	Damage   int
+	Speed    int
+	Accuracy float64
+}
+
+func (cowboyV1) TableName() string {
+	return "cowboys"
+}
+
+type cowboyV2 struct {
+	ID        string `gorm:"primaryKey"`
+	Name      string
+	Health    int
+	Damage    int
+	Speed     int
+	Accuracy  float64
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (cowboyV2) TableName() string {
+	return "cowboys"
+}
+
+type cowboyV3 struct {
+	ID        string `gorm:"primaryKey"`
+	Name      string
+	Health    int
+	Damage    int
+	Speed     int
+	Accuracy  float64
+	Version   int            `gorm:"not null;default:1"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (cowboyV3) TableName() string {
+	return "cowboys"
+}
+
+type cowboyStateV4 struct {
+	Name     string
+	Health   int
+	Damage   int
+	Speed    int
+	Accuracy float64
+}
+
+type cowboyVersionV4 struct {
+	ID        uint          `gorm:"primaryKey"`
+	CowboyID  string        `gorm:"size:191;uniqueIndex:idx_cowboy_versions_version,priority:1;index:idx_cowboy_versions_time,priority:1"`
+	Version   int           `gorm:"uniqueIndex:idx_cowboy_versions_version,priority:2"`
+	Change    string        `gorm:"size:16"`
+	Old       cowboyStateV4 `gorm:"embedded;embeddedPrefix:old_"`
+	New       cowboyStateV4 `gorm:"embedded;embeddedPrefix:new_"`
+	ChangedBy string
+	ChangedAt time.Time `gorm:"index:idx_cowboy_versions_time,priority:2"`
+}
+
+func (cowboyVersionV4) TableName() string {
+	return "cowboy_versions"
+}
@@ -3,18 +3,22 @@ package repository
 import (
 	"api/services/duelist/internal/core/domain"
 	"api/services/duelist/internal/core/ports"
+	"context"
+	"errors"
 
 	"gorm.io/gorm"
 )
 
 // DB Entity (Infrastructure Layer)
 type cowboyModel struct {
-	ID       string `gorm:"primaryKey"`
-	Name     string
-	Health   int
-	Damage   int
-	Speed    int
-	Accuracy float64
+	ID        string `gorm:"primaryKey"`
+	Name      string
+	Health    int
+	Damage    int
+	Speed     int
+	Accuracy  float64
+	Version   int            `gorm:"not null;default:1"`
+	DeletedAt gorm.DeletedAt `gorm:"index"` // soft delete: record ยังอยู่ให้ประวัติการดวลอ้างถึงได้
 }
 
 func (cowboyModel) TableName() string {
@@ -30,6 +34,7 @@ func (m *cowboyModel) toDomain() *domain.Cowboy {
 		Damage:   m.Damage,
 		Speed:    m.Speed,
 		Accuracy: m.Accuracy,
+		Version:  m.Version,
 	}
 }
 
@@ -42,6 +47,7 @@ func fromDomain(d *domain.Cowboy) *cowboyModel {
 		Damage:   d.Damage,
 		Speed:    d.Speed,
 		Accuracy: d.Accuracy,
+		Version:  d.Version,
 	}
 }
 
@@ -49,20 +55,122 @@ type mysqlRepo struct {
 	db *gorm.DB
 }
 
+// NewMySQLRepository : ตารางต้องถูกสร้างด้วย Migrations() มาก่อนแล้ว
 func NewMySQLRepository(db *gorm.DB) ports.CowboyRepository {
-	db.AutoMigrate(&cowboyModel{})
 	return &mysqlRepo{db: db}
 }
 
-func (r *mysqlRepo) Save(cowboy *domain.Cowboy) error {
-	model := fromDomain(cowboy)
-	return r.db.Create(model).Error
+// แปลง error ของ gorm ให้เป็น Domain Error (ต้องเปิด TranslateError ใน gorm.Config)
+func translateError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.ErrCowboyNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return domain.ErrCowboyAlreadyExists
+	}
+	return err
+}
+
+func (r *mysqlRepo) Save(ctx context.Context, v *domain.CowboyVersion) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(fromDomain(&v.Cowboy)).Error; err != nil {
+			return err
+		}
+		return tx.Create(versionFromDomain(v)).Error
+	})
+	return translateError(err)
 }
 
-func (r *mysqlRepo) FindByID(id string) (*domain.Cowboy, error) {
+func (r *mysqlRepo) FindByID(ctx context.Context, id string) (*domain.Cowboy, error) {
 	var model cowboyModel
-	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return model.toDomain(), nil
 }
+
+func (r *mysqlRepo) FindByIDs(ctx context.Context, ids []string) ([]*domain.Cowboy, error) {
+	var models []cowboyModel
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	cowboys := make([]*domain.Cowboy, 0, len(models))
+	for i := range models {
+		cowboys = append(cowboys, models[i].toDomain())
+	}
+	return cowboys, nil
+}
+
+func (r *mysqlRepo) Update(ctx context.Context, v *domain.CowboyVersion) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := bumpVersion(tx, &v.Cowboy); err != nil {
+			return err
+		}
+		return tx.Create(versionFromDomain(v)).Error
+	})
+	return r.checkConflict(ctx, v.Cowboy.ID, err)
+}
+
+func (r *mysqlRepo) Delete(ctx context.Context, v *domain.CowboyVersion) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := bumpVersion(tx, &v.Cowboy); err != nil {
+			return err
+		}
+		if err := tx.Delete(&cowboyModel{}, "id = ?", v.Cowboy.ID).Error; err != nil {
+			return err
+		}
+		return tx.Create(versionFromDomain(v)).Error
+	})
+	return r.checkConflict(ctx, v.Cowboy.ID, err)
+}
+
+// bumpVersion : เขียนค่าใหม่ทับ โดยมีเงื่อนไขว่า version ใน DB ต้องเป็น version ก่อนหน้า (optimistic lock)
+func bumpVersion(tx *gorm.DB, cowboy *domain.Cowboy) error {
+	// Select ระบุ column ตรงๆ เพื่อให้อัปเดตค่า zero value ได้ด้วย (เช่น accuracy = 0)
+	res := tx.Model(&cowboyModel{}).
+		Where("id = ? AND version = ?", cowboy.ID, cowboy.Version-1).
+		Select("name", "health", "damage", "speed", "accuracy", "version").
+		Updates(fromDomain(cowboy))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrVersionConflict
+	}
+	return nil
+}
+
+// checkConflict : แยกให้ออกว่าโดนแก้ตัดหน้า หรือหายไปแล้ว
+// (version ซ้ำใน cowboy_versions ก็แปลว่ามีคนบันทึกตัดหน้าเช่นกัน)
+func (r *mysqlRepo) checkConflict(ctx context.Context, id string, err error) error {
+	if errors.Is(err, domain.ErrVersionConflict) || errors.Is(err, gorm.ErrDuplicatedKey) {
+		if _, ferr := r.FindByID(ctx, id); ferr != nil {
+			return ferr
+		}
+		return domain.ErrVersionConflict
+	}
+	return translateError(err)
+}
+
+func (r *mysqlRepo) List(ctx context.Context, nameContains, afterID string, limit int) ([]*domain.Cowboy, error) {
+	var models []cowboyModel
+
+	query := r.db.WithContext(ctx).Order("id").Limit(limit)
+	if afterID != "" {
+		query = query.Where("id > ?", afterID)
+	}
+	if nameContains != "" {
+		query = query.Where("name LIKE ?", "%"+nameContains+"%")
+	}
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	cowboys := make([]*domain.Cowboy, 0, len(models))
+	for i := range models {
+		cowboys = append(cowboys, models[i].toDomain())
+	}
+	return cowboys, nil
+}
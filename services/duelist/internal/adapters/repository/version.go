@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cowboyStateModel : ค่าของ Cowboy ใน 1 บันทึก (ฝังใน cowboy_versions ทั้งค่าเก่าและค่าใหม่)
+type cowboyStateModel struct {
+	Name     string
+	Health   int
+	Damage   int
+	Speed    int
+	Accuracy float64
+}
+
+// cowboyVersionModel : ประวัติการเปลี่ยนแปลง (insert อย่างเดียว ไม่มีการแก้ / ลบ)
+type cowboyVersionModel struct {
+	ID        uint             `gorm:"primaryKey"`
+	CowboyID  string           `gorm:"size:191;uniqueIndex:idx_cowboy_versions_version,priority:1;index:idx_cowboy_versions_time,priority:1"`
+	Version   int              `gorm:"uniqueIndex:idx_cowboy_versions_version,priority:2"`
+	Change    string           `gorm:"size:16"`                      // created / updated / deleted
+	Old       cowboyStateModel `gorm:"embedded;embeddedPrefix:old_"` // ว่างถ้าเป็นการสร้าง
+	New       cowboyStateModel `gorm:"embedded;embeddedPrefix:new_"`
+	ChangedBy string
+	ChangedAt time.Time `gorm:"index:idx_cowboy_versions_time,priority:2"`
+}
+
+func (cowboyVersionModel) TableName() string {
+	return "cowboy_versions"
+}
+
+func stateFromDomain(c *domain.Cowboy) cowboyStateModel {
+	return cowboyStateModel{
+		Name:     c.Name,
+		Health:   c.Health,
+		Damage:   c.Damage,
+		Speed:    c.Speed,
+		Accuracy: c.Accuracy,
+	}
+}
+
+func (s cowboyStateModel) toDomain(id string, version int) domain.Cowboy {
+	return domain.Cowboy{
+		ID:       id,
+		Name:     s.Name,
+		Health:   s.Health,
+		Damage:   s.Damage,
+		Speed:    s.Speed,
+		Accuracy: s.Accuracy,
+		Version:  version,
+	}
+}
+
+func versionFromDomain(v *domain.CowboyVersion) *cowboyVersionModel {
+	m := &cowboyVersionModel{
+		CowboyID:  v.Cowboy.ID,
+		Version:   v.Cowboy.Version,
+		Change:    string(v.Change),
+		New:       stateFromDomain(&v.Cowboy),
+		ChangedBy: v.ChangedBy,
+		ChangedAt: v.ChangedAt,
+	}
+	if v.Previous != nil {
+		m.Old = stateFromDomain(v.Previous)
+	}
+	return m
+}
+
+func (m *cowboyVersionModel) toDomain() *domain.CowboyVersion {
+	v := &domain.CowboyVersion{
+		Change:    domain.CowboyEventType(m.Change),
+		Cowboy:    m.New.toDomain(m.CowboyID, m.Version),
+		ChangedBy: m.ChangedBy,
+		ChangedAt: m.ChangedAt,
+	}
+	if v.Change != domain.CowboyCreated {
+		prev := m.Old.toDomain(m.CowboyID, m.Version-1)
+		v.Previous = &prev
+	}
+	return v
+}
+
+func (r *mysqlRepo) FindVersion(ctx context.Context, id string, version int) (*domain.CowboyVersion, error) {
+	var m cowboyVersionModel
+	err := r.db.WithContext(ctx).
+		Where("cowboy_id = ? AND version = ?", id, version).
+		Take(&m).Error
+	if err != nil {
+		return nil, translateVersionError(err)
+	}
+	return m.toDomain(), nil
+}
+
+func (r *mysqlRepo) FindVersionAt(ctx context.Context, id string, at time.Time) (*domain.CowboyVersion, error) {
+	var m cowboyVersionModel
+	err := r.db.WithContext(ctx).
+		Where("cowboy_id = ? AND changed_at <= ?", id, at).
+		Order("changed_at DESC, version DESC").
+		Take(&m).Error
+	if err != nil {
+		return nil, translateVersionError(err)
+	}
+	return m.toDomain(), nil
+}
+
+func (r *mysqlRepo) ListVersions(ctx context.Context, id string, afterVersion, limit int) ([]*domain.CowboyVersion, error) {
+	var models []cowboyVersionModel
+	err := r.db.WithContext(ctx).
+		Where("cowboy_id = ? AND version > ?", id, afterVersion).
+		Order("version").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*domain.CowboyVersion, 0, len(models))
+	for i := range models {
+		versions = append(versions, models[i].toDomain())
+	}
+	return versions, nil
+}
+
+func translateVersionError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrVersionNotFound
+	}
+	return err
+}
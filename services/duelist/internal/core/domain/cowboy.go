@@ -1,5 +1,7 @@
 package domain
 
+import "fmt"
+
 type Cowboy struct {
 	ID       string
 	Name     string
@@ -7,4 +9,61 @@ type Cowboy struct {
 	Damage   int
 	Speed    int
 	Accuracy float64
+	// Version : เริ่มที่ 1 และเพิ่มทีละ 1 ทุกครั้งที่แก้ค่า (ฝั่ง arena เก็บไว้คู่กับผลการดวล)
+	Version int
+}
+
+// ชื่อ field ที่แก้ไขได้ (ตรงกับ path ใน update_mask ของ proto)
+const (
+	FieldName     = "name"
+	FieldHealth   = "health"
+	FieldDamage   = "damage"
+	FieldSpeed    = "speed"
+	FieldAccuracy = "accuracy"
+)
+
+// UpdatableFields : ใช้เมื่อไม่ได้ระบุ field มา (= แก้ทุก field)
+var UpdatableFields = []string{FieldName, FieldHealth, FieldDamage, FieldSpeed, FieldAccuracy}
+
+// Apply : คัดลอกค่าจาก patch มาทับเฉพาะ field ที่ระบุ
+func (c *Cowboy) Apply(patch *Cowboy, fields []string) error {
+	if len(fields) == 0 {
+		fields = UpdatableFields
+	}
+	for _, f := range fields {
+		switch f {
+		case FieldName:
+			c.Name = patch.Name
+		case FieldHealth:
+			c.Health = patch.Health
+		case FieldDamage:
+			c.Damage = patch.Damage
+		case FieldSpeed:
+			c.Speed = patch.Speed
+		case FieldAccuracy:
+			c.Accuracy = patch.Accuracy
+		default:
+			return fmt.Errorf("%w: field %q cannot be updated", ErrInvalidArgument, f)
+		}
+	}
+	return nil
+}
+
+// CowboyFilter : เงื่อนไขการดึงรายชื่อ Cowboy แบบแบ่งหน้า
+type CowboyFilter struct {
+	NameContains string
+	PageSize     int
+	PageToken    string
+}
+
+// CowboyPage : ผลลัพธ์ 1 หน้า (NextPageToken ว่าง = หน้าสุดท้าย)
+type CowboyPage struct {
+	Cowboys       []*Cowboy
+	NextPageToken string
+}
+
+// CowboyBatch : ผลของการดึงหลายตัว (Cowboys เรียงตามลำดับที่ขอ, MissingIDs = ตัวที่ไม่เจอ)
+type CowboyBatch struct {
+	Cowboys    []*Cowboy
+	MissingIDs []string
 }
@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// Domain Errors: Adapter ขาเข้า (gRPC) จะแปลงเป็น status code ที่ตรงความหมาย
+var (
+	ErrCowboyNotFound      = errors.New("cowboy not found")
+	ErrVersionNotFound     = errors.New("cowboy version not found")
+	ErrCowboyAlreadyExists = errors.New("cowboy already exists")
+	ErrInvalidArgument     = errors.New("invalid argument")
+	ErrVersionConflict     = errors.New("cowboy was modified concurrently")
+)
@@ -0,0 +1,17 @@
+package domain
+
+// CowboyEventType : ชนิดของการเปลี่ยนแปลง
+type CowboyEventType string
+
+const (
+	CowboyCreated CowboyEventType = "created"
+	CowboyUpdated CowboyEventType = "updated"
+	CowboyDeleted CowboyEventType = "deleted"
+)
+
+// CowboyEvent : แจ้งว่า Cowboy ตัวไหนเปลี่ยน (Cowboy = ค่าล่าสุด, nil ถ้าถูกลบ)
+type CowboyEvent struct {
+	Type   CowboyEventType
+	ID     string
+	Cowboy *Cowboy
+}
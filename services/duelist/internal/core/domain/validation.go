@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// กติกาสมดุลค่าพลัง (Balance Rules)
+const (
+	MaxAccuracy     = 1.0
+	StatPointBudget = 200 // แต้มค่าพลังรวมสูงสุดต่อ 1 ตัว
+)
+
+// FieldViolation : field ไหนผิด และผิดเพราะอะไร
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError : รวมทุก field ที่ผิดไว้ใน error เดียว ให้ client แก้ได้ในรอบเดียว
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Field+": "+v.Description)
+	}
+	return "invalid cowboy: " + strings.Join(msgs, "; ")
+}
+
+// Is : ให้ errors.Is(err, ErrInvalidArgument) จับ ValidationError ได้ด้วย
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// StatPoints : แต้มค่าพลังรวม
+// HP 10 หน่วย = 1 แต้ม, Damage / Speed 1 หน่วย = 1 แต้ม, Accuracy 0.01 = 1 แต้ม
+// Accuracy ต้องปัดเศษ (0.29*100 ในทศนิยมได้ 28.999...)
+func (c *Cowboy) StatPoints() int {
+	return c.Health/10 + c.Damage + c.Speed + int(math.Round(c.Accuracy*100))
+}
+
+// Validate : ตรวจช่วงค่าของแต่ละ field และงบแต้มรวม (คืน *ValidationError ถ้าไม่ผ่าน)
+func (c *Cowboy) Validate() error {
+	var vs []FieldViolation
+	add := func(field, desc string) {
+		vs = append(vs, FieldViolation{Field: field, Description: desc})
+	}
+
+	if c.ID == "" {
+		add("id", "is required")
+	}
+	if c.Health <= 0 {
+		add(FieldHealth, "must be greater than 0")
+	}
+	if c.Damage <= 0 {
+		add(FieldDamage, "must be greater than 0")
+	}
+	if c.Speed <= 0 {
+		add(FieldSpeed, "must be greater than 0")
+	}
+	// เขียนแบบ !(ช่วงที่ถูก) เพื่อให้ NaN (เทียบอะไรก็ false) ไม่หลุดผ่าน
+	if !(c.Accuracy > 0 && c.Accuracy <= MaxAccuracy) {
+		add(FieldAccuracy, fmt.Sprintf("must be in range (0, %g]", MaxAccuracy))
+	}
+
+	// เช็คงบแต้มเฉพาะตอนที่ค่าแต่ละตัวถูกต้องแล้ว (ไม่งั้นค่าติดลบจะไปหักงบ)
+	if len(vs) == 0 {
+		if points := c.StatPoints(); points > StatPointBudget {
+			add("stats", fmt.Sprintf("total stat points %d exceed budget of %d", points, StatPointBudget))
+		}
+	}
+
+	if len(vs) > 0 {
+		return &ValidationError{Violations: vs}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStatPoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		accuracy float64
+		want     int
+	}{
+		{"whole percent", 0.5, 50},
+		{"rounds instead of truncating", 0.29, 29},
+		{"max", 1.0, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cowboy{Health: 100, Damage: 20, Speed: 10, Accuracy: tt.accuracy}
+			if got := c.StatPoints() - (10 + 20 + 10); got != tt.want {
+				t.Fatalf("accuracy %v = %d points, want %d", tt.accuracy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAccuracy(t *testing.T) {
+	tests := []struct {
+		name     string
+		accuracy float64
+		wantErr  bool
+	}{
+		{"in range", 0.7, false},
+		{"max", MaxAccuracy, false},
+		{"zero", 0, true},
+		{"negative", -0.1, true},
+		{"above max", 1.01, true},
+		{"NaN", math.NaN(), true},
+		{"+Inf", math.Inf(1), true},
+		{"-Inf", math.Inf(-1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cowboy{ID: "c1", Name: "Kid", Health: 100, Damage: 20, Speed: 10, Accuracy: tt.accuracy}
+			err := c.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) || len(ve.Violations) != 1 || ve.Violations[0].Field != FieldAccuracy {
+				t.Fatalf("Validate() = %v, want a single accuracy violation", err)
+			}
+		})
+	}
+}
+
+func TestValidateBudgetAtBoundary(t *testing.T) {
+	// 0.29 เคยถูกนับเป็น 28 แต้ม ทำให้ตัวที่เกินงบ 1 แต้มหลุดผ่าน
+	c := &Cowboy{ID: "c1", Name: "Kid", Health: 1000, Damage: 36, Speed: 36, Accuracy: 0.29}
+	if c.StatPoints() != StatPointBudget+1 {
+		t.Fatalf("StatPoints() = %d, want %d", c.StatPoints(), StatPointBudget+1)
+	}
+	if err := c.Validate(); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("Validate() = %v, want budget violation", err)
+	}
+}
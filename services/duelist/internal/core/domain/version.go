@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CowboyVersion : บันทึกการเปลี่ยนแปลง 1 ครั้ง (บันทึกแล้วแก้ไม่ได้ เอาไว้ audit / ย้อนดูค่าเก่า)
+type CowboyVersion struct {
+	Change    CowboyEventType
+	Cowboy    Cowboy  // ค่าหลังเปลี่ยน (Cowboy.Version = เลข version ของบันทึกนี้)
+	Previous  *Cowboy // ค่าก่อนเปลี่ยน (nil = ตอนสร้าง)
+	ChangedBy string
+	ChangedAt time.Time
+}
+
+// NewCowboyVersion : สร้างบันทึกจากค่าก่อน / หลังเปลี่ยน (เก็บเป็นสำเนา ผู้เรียกแก้ตัวเดิมต่อได้)
+func NewCowboyVersion(change CowboyEventType, cowboy, previous *Cowboy, by string) *CowboyVersion {
+	v := &CowboyVersion{
+		Change:    change,
+		Cowboy:    *cowboy,
+		ChangedBy: by,
+		ChangedAt: time.Now().UTC(),
+	}
+	if previous != nil {
+		prev := *previous
+		v.Previous = &prev
+	}
+	return v
+}
+
+// CowboyVersionFilter : ดึงประวัติของ Cowboy 1 ตัว เรียงจาก version เก่าไปใหม่
+type CowboyVersionFilter struct {
+	ID        string
+	PageSize  int
+	PageToken string
+}
+
+// CowboyVersionPage : ผลลัพธ์ 1 หน้า (NextPageToken ว่าง = หน้าสุดท้าย)
+type CowboyVersionPage struct {
+	Versions      []*CowboyVersion
+	NextPageToken string
+}
+
+// UnknownActor : ใช้เมื่อผู้เรียกไม่ได้บอกว่าเป็นใคร
+const UnknownActor = "unknown"
+
+type actorKey struct{}
+
+// WithActor : แนบชื่อผู้สั่งเปลี่ยนไปกับ ctx (Adapter ขาเข้าเป็นคนใส่)
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom : ชื่อผู้สั่งเปลี่ยนที่แนบมากับ ctx (ไม่มี = UnknownActor)
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return UnknownActor
+}
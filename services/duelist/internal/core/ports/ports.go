@@ -1,15 +1,56 @@
 package ports
 
-import "api/services/duelist/internal/core/domain"
+import (
+	"api/services/duelist/internal/core/domain"
+	"context"
+	"time"
+)
 
 // Primary Port (Inbound): สิ่งที่ Service นี้ทำได้
 type DuelistService interface {
-	Create(cowboy *domain.Cowboy) (*domain.Cowboy, error)
-	Get(id string) (*domain.Cowboy, error)
+	Create(ctx context.Context, cowboy *domain.Cowboy) (*domain.Cowboy, error)
+	Get(ctx context.Context, id string) (*domain.Cowboy, error)
+	// GetVersion : ค่าของ Cowboy ณ version ที่ระบุ
+	GetVersion(ctx context.Context, id string, version int) (*domain.Cowboy, error)
+	// GetAt : ค่าของ Cowboy ณ เวลาที่ระบุ (ตอนนั้นยังไม่สร้าง / ถูกลบไปแล้ว = ErrVersionNotFound)
+	GetAt(ctx context.Context, id string, at time.Time) (*domain.Cowboy, error)
+	// ListVersions : ประวัติการเปลี่ยนแปลงทั้งหมด เรียงจากเก่าไปใหม่
+	ListVersions(ctx context.Context, filter domain.CowboyVersionFilter) (*domain.CowboyVersionPage, error)
+	// BatchGet : ดึงหลายตัวในครั้งเดียว ตัวที่ไม่เจอไม่ถือเป็น error
+	BatchGet(ctx context.Context, ids []string) (*domain.CowboyBatch, error)
+	// Update : แก้เฉพาะ fields ที่ระบุ (ว่าง = ทุก field)
+	Update(ctx context.Context, cowboy *domain.Cowboy, fields []string) (*domain.Cowboy, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, filter domain.CowboyFilter) (*domain.CowboyPage, error)
+	// Watch : รับ event การเปลี่ยนแปลงไปจนกว่า ctx จะจบ
+	// channel ถูกปิดเมื่อ ctx จบ หรือรับ event ไม่ทัน (ผู้ฟังต้องต่อใหม่และถือว่าพลาด event ไป)
+	Watch(ctx context.Context) <-chan domain.CowboyEvent
 }
 
 // Secondary Port (Outbound): สิ่งที่ Service นี้ต้องการจากภายนอก (DB)
+// Save / Update / Delete บันทึก version ลงประวัติใน transaction เดียวกับการเปลี่ยนค่า
 type CowboyRepository interface {
-	Save(cowboy *domain.Cowboy) error
-	FindByID(id string) (*domain.Cowboy, error)
-}
\ No newline at end of file
+	Save(ctx context.Context, v *domain.CowboyVersion) error
+	FindByID(ctx context.Context, id string) (*domain.Cowboy, error)
+	// FindByIDs : คืนเฉพาะตัวที่เจอ (ลำดับไม่รับประกัน)
+	FindByIDs(ctx context.Context, ids []string) ([]*domain.Cowboy, error)
+	// Update : v.Cowboy.Version คือ version ใหม่ จะบันทึกได้ก็ต่อเมื่อใน DB ยังเป็น Version-1
+	// (มีคนแก้ตัดหน้าไปก่อน = ErrVersionConflict)
+	Update(ctx context.Context, v *domain.CowboyVersion) error
+	// Delete : soft delete (FindByID / List จะมองไม่เห็นอีก) เช็ค version แบบเดียวกับ Update
+	Delete(ctx context.Context, v *domain.CowboyVersion) error
+	// List : เรียงตาม ID และเอาเฉพาะ ID ที่มากกว่า afterID (keyset pagination)
+	List(ctx context.Context, nameContains, afterID string, limit int) ([]*domain.Cowboy, error)
+
+	FindVersion(ctx context.Context, id string, version int) (*domain.CowboyVersion, error)
+	// FindVersionAt : บันทึกล่าสุดที่เกิดก่อนหรือตรงกับเวลา at
+	FindVersionAt(ctx context.Context, id string, at time.Time) (*domain.CowboyVersion, error)
+	// ListVersions : เรียงตาม version และเอาเฉพาะที่มากกว่า afterVersion
+	ListVersions(ctx context.Context, id string, afterVersion, limit int) ([]*domain.CowboyVersion, error)
+}
+
+// Secondary Port (Outbound): กระจาย event ไปยังผู้ฟังทุกคน
+type CowboyEventBroker interface {
+	Publish(event domain.CowboyEvent)
+	Subscribe(ctx context.Context) <-chan domain.CowboyEvent
+}
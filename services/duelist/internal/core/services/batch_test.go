@@ -0,0 +1,77 @@
+package services
+
+import (
+	"api/services/duelist/internal/adapters/repository"
+	"api/services/duelist/internal/core/domain"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// manyIDs : c000, c001, ... (ไม่ได้สร้างไว้ = missing)
+func manyIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("c%03d", i)
+	}
+	return ids
+}
+
+func TestBatchGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []string
+		wantIDs     []string
+		wantMissing []string
+		wantErr     error
+	}{
+		{"keeps requested order", []string{"doc", "kid"}, []string{"doc", "kid"}, nil, nil},
+		{"duplicates collapse", []string{"kid", "doc", "kid", "kid"}, []string{"kid", "doc"}, nil, nil},
+		{"missing reported in order", []string{"ghost", "kid", "nobody", "ghost"}, []string{"kid"}, []string{"ghost", "nobody"}, nil},
+		{"deleted counts as missing", []string{"kid", "gone"}, []string{"kid"}, []string{"gone"}, nil},
+		{"empty", nil, nil, nil, domain.ErrInvalidArgument},
+		{"at the limit", manyIDs(maxBatchSize), nil, manyIDs(maxBatchSize), nil},
+		{"over the limit", manyIDs(maxBatchSize + 1), nil, nil, domain.ErrInvalidArgument},
+		{"over the limit only before dedup", append(manyIDs(maxBatchSize), "c000"), nil, manyIDs(maxBatchSize), nil},
+	}
+	for name, open := range repoVariants(t) {
+		s := newTestService(open())
+		mustCreate(t, s, "kid", "doc", "gone")
+		if err := s.Delete(context.Background(), "gone"); err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				batch, err := s.BatchGet(context.Background(), tt.ids)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("BatchGet() = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				var ids []string
+				for _, c := range batch.Cowboys {
+					ids = append(ids, c.ID)
+				}
+				if !slices.Equal(ids, tt.wantIDs) || !slices.Equal(batch.MissingIDs, tt.wantMissing) {
+					t.Fatalf("BatchGet() = %v missing %v, want %v missing %v", ids, batch.MissingIDs, tt.wantIDs, tt.wantMissing)
+				}
+			})
+		}
+	}
+}
+
+func TestBatchGetReturnsCopies(t *testing.T) {
+	s := newTestService(repository.NewMemoryRepository())
+	mustCreate(t, s, "kid")
+	batch, err := s.BatchGet(context.Background(), []string{"kid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch.Cowboys[0].Health = 1
+	if c, _ := s.Get(context.Background(), "kid"); c.Health != 100 {
+		t.Fatalf("changing the batch result changed the stored cowboy: health %d", c.Health)
+	}
+}
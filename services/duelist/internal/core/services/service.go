@@ -3,27 +3,236 @@ package services
 import (
 	"api/services/duelist/internal/core/domain"
 	"api/services/duelist/internal/core/ports"
+	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxBatchSize    = 100
 )
 
 type service struct {
-	repo ports.CowboyRepository
+	repo   ports.CowboyRepository
+	events ports.CowboyEventBroker
 }
 
-func NewDuelistService(repo ports.CowboyRepository) ports.DuelistService {
-	return &service{repo: repo}
+func NewDuelistService(repo ports.CowboyRepository, events ports.CowboyEventBroker) ports.DuelistService {
+	return &service{repo: repo, events: events}
 }
 
-func (s *service) Create(cowboy *domain.Cowboy) (*domain.Cowboy, error) {
-	if cowboy.ID == "" {
-		return nil, errors.New("ID is required")
+func (s *service) Create(ctx context.Context, cowboy *domain.Cowboy) (*domain.Cowboy, error) {
+	if err := cowboy.Validate(); err != nil {
+		return nil, err
 	}
-	if err := s.repo.Save(cowboy); err != nil {
+	cowboy.Version = 1
+	if err := s.repo.Save(ctx, domain.NewCowboyVersion(domain.CowboyCreated, cowboy, nil, domain.ActorFrom(ctx))); err != nil {
 		return nil, err
 	}
+	s.publish(domain.CowboyCreated, cowboy.ID, cowboy)
 	return cowboy, nil
 }
 
-func (s *service) Get(id string) (*domain.Cowboy, error) {
-	return s.repo.FindByID(id)
-}
\ No newline at end of file
+func (s *service) Get(ctx context.Context, id string) (*domain.Cowboy, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *service) BatchGet(ctx context.Context, ids []string) (*domain.CowboyBatch, error) {
+	// รวม ID ซ้ำ แต่คงลำดับตามที่ขอมา
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: ids is required", domain.ErrInvalidArgument)
+	}
+	if len(unique) > maxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d ids per batch", domain.ErrInvalidArgument, maxBatchSize)
+	}
+
+	found, err := s.repo.FindByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Cowboy, len(found))
+	for _, c := range found {
+		byID[c.ID] = c
+	}
+
+	batch := &domain.CowboyBatch{Cowboys: make([]*domain.Cowboy, 0, len(found))}
+	for _, id := range unique {
+		if c, ok := byID[id]; ok {
+			batch.Cowboys = append(batch.Cowboys, c)
+		} else {
+			batch.MissingIDs = append(batch.MissingIDs, id)
+		}
+	}
+	return batch, nil
+}
+
+func (s *service) Update(ctx context.Context, cowboy *domain.Cowboy, fields []string) (*domain.Cowboy, error) {
+	// 1. ดึงของเดิมมาก่อน แล้วค่อยทับเฉพาะ field ที่ขอแก้
+	current, err := s.repo.FindByID(ctx, cowboy.ID)
+	if err != nil {
+		return nil, err
+	}
+	previous := *current
+	if err := current.Apply(cowboy, fields); err != nil {
+		return nil, err
+	}
+	// ค่าหลังแก้ต้องผ่านกติกาเหมือนตอนสร้าง
+	if err := current.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 2. บันทึกกลับลง DB พร้อมขยับ version
+	current.Version++
+	v := domain.NewCowboyVersion(domain.CowboyUpdated, current, &previous, domain.ActorFrom(ctx))
+	if err := s.repo.Update(ctx, v); err != nil {
+		return nil, err
+	}
+	s.publish(domain.CowboyUpdated, current.ID, current)
+	return current, nil
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	current, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// การลบก็นับเป็น 1 version (ค่าเหมือนเดิม แต่ดึงย้อนเวลาหลังจากนี้จะไม่เจอ)
+	deleted := *current
+	deleted.Version++
+	if err := s.repo.Delete(ctx, domain.NewCowboyVersion(domain.CowboyDeleted, &deleted, current, domain.ActorFrom(ctx))); err != nil {
+		return err
+	}
+	s.publish(domain.CowboyDeleted, id, nil)
+	return nil
+}
+
+func (s *service) Watch(ctx context.Context) <-chan domain.CowboyEvent {
+	return s.events.Subscribe(ctx)
+}
+
+// publish : แจ้งผู้ฟังหลังบันทึกสำเร็จ (ส่งสำเนาไป กันผู้ฟังแก้ค่าของผู้เรียก)
+func (s *service) publish(t domain.CowboyEventType, id string, cowboy *domain.Cowboy) {
+	event := domain.CowboyEvent{Type: t, ID: id}
+	if cowboy != nil {
+		cp := *cowboy
+		event.Cowboy = &cp
+	}
+	s.events.Publish(event)
+}
+
+func (s *service) List(ctx context.Context, filter domain.CowboyFilter) (*domain.CowboyPage, error) {
+	size := filter.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	// page token = ID ตัวสุดท้ายของหน้าก่อน (encode ไว้ไม่ให้ client ไปพึ่งรูปแบบข้างใน)
+	afterID, err := base64.RawURLEncoding.DecodeString(filter.PageToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page token", domain.ErrInvalidArgument)
+	}
+
+	// ขอเกินมา 1 ตัว เพื่อดูว่ายังมีหน้าถัดไปไหม
+	cowboys, err := s.repo.List(ctx, filter.NameContains, string(afterID), size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.CowboyPage{Cowboys: cowboys}
+	if len(cowboys) > size {
+		page.Cowboys = cowboys[:size]
+		page.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(cowboys[size-1].ID))
+	}
+	return page, nil
+}
+
+func (s *service) GetVersion(ctx context.Context, id string, version int) (*domain.Cowboy, error) {
+	if version <= 0 {
+		return nil, fmt.Errorf("%w: version must be positive", domain.ErrInvalidArgument)
+	}
+	v, err := s.repo.FindVersion(ctx, id, version)
+	if errors.Is(err, domain.ErrVersionNotFound) {
+		// Cowboy ที่สร้างก่อนเริ่มเก็บประวัติ ยังขอ version ปัจจุบันได้
+		if current, cerr := s.repo.FindByID(ctx, id); cerr == nil && current.Version == version {
+			return current, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stateOf(v)
+}
+
+func (s *service) GetAt(ctx context.Context, id string, at time.Time) (*domain.Cowboy, error) {
+	v, err := s.repo.FindVersionAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+	return stateOf(v)
+}
+
+// stateOf : ค่าของ Cowboy ตามบันทึก (บันทึกการลบ = ตอนนั้นไม่มีตัวตนแล้ว)
+func stateOf(v *domain.CowboyVersion) (*domain.Cowboy, error) {
+	if v.Change == domain.CowboyDeleted {
+		return nil, fmt.Errorf("%w: cowboy was deleted at version %d", domain.ErrVersionNotFound, v.Cowboy.Version)
+	}
+	c := v.Cowboy
+	return &c, nil
+}
+
+func (s *service) ListVersions(ctx context.Context, filter domain.CowboyVersionFilter) (*domain.CowboyVersionPage, error) {
+	size := filter.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	// page token = version ตัวสุดท้ายของหน้าก่อน
+	afterVersion := 0
+	if filter.PageToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(filter.PageToken)
+		if err == nil {
+			afterVersion, err = strconv.Atoi(string(raw))
+		}
+		if err != nil || afterVersion < 0 {
+			return nil, fmt.Errorf("%w: invalid page token", domain.ErrInvalidArgument)
+		}
+	}
+
+	versions, err := s.repo.ListVersions(ctx, filter.ID, afterVersion, size+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 && afterVersion == 0 {
+		// ไม่มีประวัติเลย: ถ้าไม่มีตัวตนด้วยให้ตอบ not found (ตัวเก่าก่อนเริ่มเก็บประวัติ = หน้าว่าง)
+		if _, err := s.repo.FindByID(ctx, filter.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	page := &domain.CowboyVersionPage{Versions: versions}
+	if len(versions) > size {
+		page.Versions = versions[:size]
+		last := versions[size-1].Cowboy.Version
+		page.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(last)))
+	}
+	return page, nil
+}
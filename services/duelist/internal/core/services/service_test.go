@@ -0,0 +1,249 @@
+package services
+
+import (
+	"api/pkg/database"
+	"api/services/duelist/internal/adapters/broker"
+	"api/services/duelist/internal/adapters/repository"
+	"api/services/duelist/internal/core/domain"
+	"api/services/duelist/internal/core/ports"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// repoVariants : service ต้องทำงานเหมือนกันไม่ว่าจะใช้ repository แบบไหน
+func repoVariants(t *testing.T) map[string]func() ports.CowboyRepository {
+	return map[string]func() ports.CowboyRepository{
+		"gorm": func() ports.CowboyRepository {
+			ctx := context.Background()
+			db, err := database.Open(ctx, database.Options{DSN: "sqlite://" + filepath.Join(t.TempDir(), "duelist.db")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+			m, err := database.NewMigrator(db.Primary(), "duelist", repository.Migrations())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := m.Up(ctx); err != nil {
+				t.Fatal(err)
+			}
+			return repository.NewMySQLRepository(db.Primary())
+		},
+		"memory": repository.NewMemoryRepository,
+	}
+}
+
+func newTestService(repo ports.CowboyRepository) ports.DuelistService {
+	return NewDuelistService(repo, broker.NewMemoryBroker())
+}
+
+func testCowboy(id string) *domain.Cowboy {
+	return &domain.Cowboy{ID: id, Name: "Kid " + id, Health: 100, Damage: 20, Speed: 10, Accuracy: 0.5}
+}
+
+func mustCreate(t *testing.T, s ports.DuelistService, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if _, err := s.Create(context.Background(), testCowboy(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUpdateFieldMask(t *testing.T) {
+	// patch มีค่าใหม่ทุก field (Version ใน patch ต้องไม่ถูกใช้ version ขยับจาก 1 เป็น 2 เสมอ)
+	patch := domain.Cowboy{ID: "kid", Name: "Billy", Health: 80, Damage: 25, Speed: 12, Accuracy: 0.7, Version: 99}
+	invalid := domain.Cowboy{ID: "kid", Name: "Billy", Damage: 0, Speed: -1}
+	tests := []struct {
+		name    string
+		patch   domain.Cowboy
+		fields  []string
+		want    domain.Cowboy
+		wantErr error
+	}{
+		{"single field", patch, []string{domain.FieldName}, domain.Cowboy{ID: "kid", Name: "Billy", Health: 100, Damage: 20, Speed: 10, Accuracy: 0.5, Version: 2}, nil},
+		{"several fields", patch, []string{domain.FieldHealth, domain.FieldAccuracy}, domain.Cowboy{ID: "kid", Name: "Kid kid", Health: 80, Damage: 20, Speed: 10, Accuracy: 0.7, Version: 2}, nil},
+		{"empty mask = every field", patch, nil, domain.Cowboy{ID: "kid", Name: "Billy", Health: 80, Damage: 25, Speed: 12, Accuracy: 0.7, Version: 2}, nil},
+		{"fields outside the mask are ignored", invalid, []string{domain.FieldName}, domain.Cowboy{ID: "kid", Name: "Billy", Health: 100, Damage: 20, Speed: 10, Accuracy: 0.5, Version: 2}, nil},
+		{"unknown path", patch, []string{domain.FieldName, "luck"}, domain.Cowboy{}, domain.ErrInvalidArgument},
+		{"immutable id", patch, []string{"id"}, domain.Cowboy{}, domain.ErrInvalidArgument},
+		{"immutable version", patch, []string{"version"}, domain.Cowboy{}, domain.ErrInvalidArgument},
+		{"masked value breaks the rules", invalid, []string{domain.FieldDamage, domain.FieldSpeed}, domain.Cowboy{}, domain.ErrInvalidArgument},
+	}
+	for name, open := range repoVariants(t) {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				s := newTestService(open())
+				mustCreate(t, s, "kid")
+
+				p := tt.patch
+				got, err := s.Update(context.Background(), &p, tt.fields)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Update() = %v, want %v", err, tt.wantErr)
+				}
+				stored, _ := s.Get(context.Background(), "kid")
+				if tt.wantErr != nil {
+					// ไม่ผ่าน = ไม่มีอะไรเปลี่ยน
+					if want := *testCowboy("kid"); stored.Name != want.Name || stored.Health != want.Health || stored.Version != 1 {
+						t.Fatalf("rejected update changed the cowboy: %+v", *stored)
+					}
+					return
+				}
+				if *got != tt.want || *stored != tt.want {
+					t.Fatalf("Update() = %+v, stored %+v, want %+v", *got, *stored, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestDeleteHidesCowboy(t *testing.T) {
+	for name, open := range repoVariants(t) {
+		t.Run(name, func(t *testing.T) {
+			s := newTestService(open())
+			ctx := context.Background()
+			mustCreate(t, s, "ace", "kid", "doc")
+
+			if err := s.Delete(ctx, "kid"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Get(ctx, "kid"); !errors.Is(err, domain.ErrCowboyNotFound) {
+				t.Fatalf("Get after delete = %v, want ErrCowboyNotFound", err)
+			}
+			page, err := s.List(ctx, domain.CowboyFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, c := range page.Cowboys {
+				ids = append(ids, c.ID)
+			}
+			if !slices.Equal(ids, []string{"ace", "doc"}) {
+				t.Fatalf("List after delete = %v, want [ace doc]", ids)
+			}
+
+			// ลบซ้ำ / แก้ตัวที่ลบแล้ว = ไม่เจอ และ ID เดิมยังสร้างใหม่ไม่ได้ (record ยังอยู่ให้ประวัติการดวลอ้างถึง)
+			if err := s.Delete(ctx, "kid"); !errors.Is(err, domain.ErrCowboyNotFound) {
+				t.Fatalf("second Delete = %v, want ErrCowboyNotFound", err)
+			}
+			if _, err := s.Update(ctx, testCowboy("kid"), nil); !errors.Is(err, domain.ErrCowboyNotFound) {
+				t.Fatalf("Update after delete = %v, want ErrCowboyNotFound", err)
+			}
+			if _, err := s.Create(ctx, testCowboy("kid")); !errors.Is(err, domain.ErrCowboyAlreadyExists) {
+				t.Fatalf("Create with a deleted ID = %v, want ErrCowboyAlreadyExists", err)
+			}
+		})
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	tests := []struct {
+		pageSize  int
+		wantPages int
+	}{
+		{1, 5},
+		{2, 3},
+		{5, 1}, // พอดีหน้าเดียว ต้องไม่มี token ชี้ไปหน้าว่าง
+		{6, 1},
+		{0, 1}, // 0 = ค่า default
+	}
+	want := []string{"a1", "a2", "a3", "a4", "a5"}
+	for name, open := range repoVariants(t) {
+		s := newTestService(open())
+		mustCreate(t, s, "a3", "a1", "a5", "a2", "a4", "gone")
+		if err := s.Delete(context.Background(), "gone"); err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/page_size=%d", name, tt.pageSize), func(t *testing.T) {
+				var ids []string
+				filter := domain.CowboyFilter{PageSize: tt.pageSize}
+				for pages := 1; ; pages++ {
+					page, err := s.List(context.Background(), filter)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if tt.pageSize > 0 && len(page.Cowboys) > tt.pageSize {
+						t.Fatalf("page %d has %d cowboys, page size %d", pages, len(page.Cowboys), tt.pageSize)
+					}
+					for _, c := range page.Cowboys {
+						ids = append(ids, c.ID)
+					}
+					if page.NextPageToken == "" {
+						if pages != tt.wantPages {
+							t.Fatalf("pages = %d, want %d", pages, tt.wantPages)
+						}
+						break
+					}
+					if pages > len(want) {
+						t.Fatal("pagination does not terminate")
+					}
+					filter.PageToken = page.NextPageToken
+				}
+				if !slices.Equal(ids, want) {
+					t.Fatalf("ids = %v, want %v", ids, want)
+				}
+			})
+		}
+	}
+}
+
+func TestListRejectsBadPageToken(t *testing.T) {
+	s := newTestService(repository.NewMemoryRepository())
+	if _, err := s.List(context.Background(), domain.CowboyFilter{PageToken: "%%%"}); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("List() = %v, want ErrInvalidArgument", err)
+	}
+}
+
+// racingRepo : มีคนแก้ตัดหน้าหลังจาก Update อ่านค่าเดิมไปแล้ว (ครั้งเดียว)
+type racingRepo struct {
+	ports.CowboyRepository
+	race func()
+}
+
+func (r *racingRepo) FindByID(ctx context.Context, id string) (*domain.Cowboy, error) {
+	c, err := r.CowboyRepository.FindByID(ctx, id)
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return c, err
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	for name, open := range repoVariants(t) {
+		t.Run(name, func(t *testing.T) {
+			repo := open()
+			other := newTestService(repo)
+			mustCreate(t, other, "kid")
+
+			ctx := context.Background()
+			racing := &racingRepo{CowboyRepository: repo, race: func() {
+				if _, err := other.Update(ctx, &domain.Cowboy{ID: "kid", Name: "First"}, []string{domain.FieldName}); err != nil {
+					t.Fatal(err)
+				}
+			}}
+			_, err := newTestService(racing).Update(ctx, &domain.Cowboy{ID: "kid", Health: 50}, []string{domain.FieldHealth})
+			if !errors.Is(err, domain.ErrVersionConflict) {
+				t.Fatalf("stale Update = %v, want ErrVersionConflict", err)
+			}
+
+			// ตัวที่ชนะยังอยู่ครบ ตัวที่แพ้ไม่ทิ้งอะไรไว้ในค่าหรือในประวัติ
+			got, _ := other.Get(ctx, "kid")
+			if got.Name != "First" || got.Health != 100 || got.Version != 2 {
+				t.Fatalf("cowboy after conflict = %+v, want the first writer's version 2", *got)
+			}
+			page, err := other.ListVersions(ctx, domain.CowboyVersionFilter{ID: "kid"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page.Versions) != 2 {
+				t.Fatalf("history has %d versions, want 2", len(page.Versions))
+			}
+		})
+	}
+}
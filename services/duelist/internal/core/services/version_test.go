@@ -0,0 +1,154 @@
+package services
+
+import (
+	"api/services/duelist/internal/core/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEveryChangeAppendsOneVersion(t *testing.T) {
+	steps := []struct {
+		change domain.CowboyEventType
+		run    func(ctx context.Context, s *service) error
+	}{
+		{domain.CowboyCreated, func(ctx context.Context, s *service) error {
+			_, err := s.Create(ctx, testCowboy("kid"))
+			return err
+		}},
+		{domain.CowboyUpdated, func(ctx context.Context, s *service) error {
+			_, err := s.Update(ctx, &domain.Cowboy{ID: "kid", Name: "Billy"}, []string{domain.FieldName})
+			return err
+		}},
+		{domain.CowboyUpdated, func(ctx context.Context, s *service) error {
+			_, err := s.Update(ctx, &domain.Cowboy{ID: "kid", Health: 60}, []string{domain.FieldHealth})
+			return err
+		}},
+		{domain.CowboyDeleted, func(ctx context.Context, s *service) error {
+			return s.Delete(ctx, "kid")
+		}},
+	}
+	for name, open := range repoVariants(t) {
+		t.Run(name, func(t *testing.T) {
+			s := newTestService(open()).(*service)
+			ctx := domain.WithActor(context.Background(), "alice")
+
+			var prev *domain.CowboyVersion
+			for i, step := range steps {
+				if err := step.run(ctx, s); err != nil {
+					t.Fatalf("step %d (%s): %v", i+1, step.change, err)
+				}
+				page, err := s.ListVersions(ctx, domain.CowboyVersionFilter{ID: "kid"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(page.Versions) != i+1 {
+					t.Fatalf("after %s history has %d versions, want %d", step.change, len(page.Versions), i+1)
+				}
+				v := page.Versions[i]
+				if v.Change != step.change || v.Cowboy.Version != i+1 || v.ChangedBy != "alice" {
+					t.Fatalf("version %d = %s v%d by %q, want %s v%d by alice", i+1, v.Change, v.Cowboy.Version, v.ChangedBy, step.change, i+1)
+				}
+				// Previous ต้องเป็นค่าของบันทึกก่อนหน้าพอดี
+				switch {
+				case prev == nil && v.Previous != nil:
+					t.Fatalf("create has previous %+v", *v.Previous)
+				case prev != nil && (v.Previous == nil || *v.Previous != prev.Cowboy):
+					t.Fatalf("version %d previous = %+v, want %+v", i+1, v.Previous, prev.Cowboy)
+				}
+				prev = v
+			}
+		})
+	}
+}
+
+func TestReadPastVersions(t *testing.T) {
+	for name, open := range repoVariants(t) {
+		t.Run(name, func(t *testing.T) {
+			s := newTestService(open())
+			ctx := context.Background()
+
+			beforeCreate := time.Now().UTC().Add(-time.Second)
+			mustCreate(t, s, "kid")
+			time.Sleep(2 * time.Millisecond)
+			if _, err := s.Update(ctx, &domain.Cowboy{ID: "kid", Name: "Billy"}, []string{domain.FieldName}); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(2 * time.Millisecond)
+			if err := s.Delete(ctx, "kid"); err != nil {
+				t.Fatal(err)
+			}
+			page, err := s.ListVersions(ctx, domain.CowboyVersionFilter{ID: "kid"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			created, updated, deleted := page.Versions[0].ChangedAt, page.Versions[1].ChangedAt, page.Versions[2].ChangedAt
+
+			tests := []struct {
+				name     string
+				get      func() (*domain.Cowboy, error)
+				wantName string
+				wantErr  error
+			}{
+				{"as_of before creation", func() (*domain.Cowboy, error) { return s.GetAt(ctx, "kid", beforeCreate) }, "", domain.ErrVersionNotFound},
+				{"as_of at creation", func() (*domain.Cowboy, error) { return s.GetAt(ctx, "kid", created) }, "Kid kid", nil},
+				{"as_of between update and delete", func() (*domain.Cowboy, error) { return s.GetAt(ctx, "kid", updated.Add(time.Millisecond)) }, "Billy", nil},
+				{"as_of after delete", func() (*domain.Cowboy, error) { return s.GetAt(ctx, "kid", deleted) }, "", domain.ErrVersionNotFound},
+				{"version 1 after delete", func() (*domain.Cowboy, error) { return s.GetVersion(ctx, "kid", 1) }, "Kid kid", nil},
+				{"version 2 after delete", func() (*domain.Cowboy, error) { return s.GetVersion(ctx, "kid", 2) }, "Billy", nil},
+				{"delete version", func() (*domain.Cowboy, error) { return s.GetVersion(ctx, "kid", 3) }, "", domain.ErrVersionNotFound},
+				{"future version", func() (*domain.Cowboy, error) { return s.GetVersion(ctx, "kid", 4) }, "", domain.ErrVersionNotFound},
+				{"version 0", func() (*domain.Cowboy, error) { return s.GetVersion(ctx, "kid", 0) }, "", domain.ErrInvalidArgument},
+				{"unknown cowboy", func() (*domain.Cowboy, error) { return s.GetAt(ctx, "ghost", deleted) }, "", domain.ErrVersionNotFound},
+			}
+			for _, tt := range tests {
+				c, err := tt.get()
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+					continue
+				}
+				if err == nil && c.Name != tt.wantName {
+					t.Errorf("%s: name = %q, want %q", tt.name, c.Name, tt.wantName)
+				}
+			}
+		})
+	}
+}
+
+func TestVersionRecordsActor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"from ctx", domain.WithActor(context.Background(), "alice"), "alice"},
+		{"not set", context.Background(), domain.UnknownActor},
+		{"empty", domain.WithActor(context.Background(), ""), domain.UnknownActor},
+	}
+	for name, open := range repoVariants(t) {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				s := newTestService(open())
+				if _, err := s.Create(tt.ctx, testCowboy("kid")); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := s.Update(tt.ctx, &domain.Cowboy{ID: "kid", Speed: 11}, []string{domain.FieldSpeed}); err != nil {
+					t.Fatal(err)
+				}
+				if err := s.Delete(tt.ctx, "kid"); err != nil {
+					t.Fatal(err)
+				}
+				page, err := s.ListVersions(context.Background(), domain.CowboyVersionFilter{ID: "kid"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, v := range page.Versions {
+					if v.ChangedBy != tt.want {
+						t.Fatalf("%s v%d changed by %q, want %q", v.Change, v.Cowboy.Version, v.ChangedBy, tt.want)
+					}
+				}
+			})
+		}
+	}
+}